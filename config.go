@@ -1,6 +1,17 @@
 package gotel
 
-import "errors"
+import (
+	"errors"
+	"maps"
+	"time"
+)
+
+const (
+	otlpDefaultTimeout     = 10 * time.Second
+	defaultTracesFilePath  = "traces.jsonl"
+	defaultMetricsFilePath = "metrics.jsonl"
+	defaultLogsFilePath    = "logs.jsonl"
+)
 
 // OTLPCompressionType represents the compression type enum for OTLP.
 type OTLPCompressionType string
@@ -32,6 +43,19 @@ const (
 	OTELMetricsExporterOTLP OTELMetricsExporterType = "otlp"
 	// OTELMetricsExporterPrometheus represents an enum that enables the metrics exporter via Prometheus.
 	OTELMetricsExporterPrometheus OTELMetricsExporterType = "prometheus"
+	// OTELMetricsExporterConsole represents an enum that prints metrics to the standard output.
+	OTELMetricsExporterConsole OTELMetricsExporterType = "console"
+	// OTELMetricsExporterFile represents an enum that writes metrics to a file in the OTLP JSON lines format.
+	OTELMetricsExporterFile OTELMetricsExporterType = "file"
+	// OTELMetricsExporterPushgateway represents an enum that pushes metrics to a Prometheus Pushgateway
+	// periodically and on shutdown.
+	OTELMetricsExporterPushgateway OTELMetricsExporterType = "pushgateway"
+	// OTELMetricsExporterPrometheusRemoteWrite represents an enum that sends metrics to the OTLP metrics endpoint
+	// with the Prometheus remote-write protocol. The protocol version 1.0 has no native histograms,
+	// so metric views of the base2_exponential_bucket_histogram aggregation are rejected.
+	OTELMetricsExporterPrometheusRemoteWrite OTELMetricsExporterType = "prometheusremotewrite"
+	// OTELMetricsExporterStatsd represents an enum that sends metrics to a StatsD agent in UDP lines.
+	OTELMetricsExporterStatsd OTELMetricsExporterType = "statsd"
 )
 
 // OTELLogsExporterType defines the type of OpenTelemetry logs exporter.
@@ -42,26 +66,291 @@ const (
 	OTELLogsExporterNone OTELLogsExporterType = "none"
 	// OTELLogsExporterOTLP represents an enum that enables the logs exporter via OTLP protocol.
 	OTELLogsExporterOTLP OTELLogsExporterType = "otlp"
+	// OTELLogsExporterConsole represents an enum that prints logs to the standard output.
+	OTELLogsExporterConsole OTELLogsExporterType = "console"
+	// OTELLogsExporterFile represents an enum that writes logs to a file in the OTLP JSON lines format.
+	OTELLogsExporterFile OTELLogsExporterType = "file"
+)
+
+// OTELTracesExporterType defines the type of OpenTelemetry traces exporter.
+type OTELTracesExporterType string
+
+const (
+	// OTELTracesExporterNone represents an enum that disables the traces exporter.
+	OTELTracesExporterNone OTELTracesExporterType = "none"
+	// OTELTracesExporterOTLP represents an enum that enables the traces exporter via OTLP protocol.
+	OTELTracesExporterOTLP OTELTracesExporterType = "otlp"
+	// OTELTracesExporterConsole represents an enum that prints traces to the standard output.
+	OTELTracesExporterConsole OTELTracesExporterType = "console"
+	// OTELTracesExporterFile represents an enum that writes traces to a file in the OTLP JSON lines format.
+	OTELTracesExporterFile OTELTracesExporterType = "file"
+)
+
+// OTELTracesSamplerType defines the type of OpenTelemetry traces sampler.
+type OTELTracesSamplerType string
+
+const (
+	// OTELTracesSamplerAlwaysOn represents an enum that samples every trace.
+	OTELTracesSamplerAlwaysOn OTELTracesSamplerType = "always_on"
+	// OTELTracesSamplerAlwaysOff represents an enum that drops every trace.
+	OTELTracesSamplerAlwaysOff OTELTracesSamplerType = "always_off"
+	// OTELTracesSamplerTraceIDRatio represents an enum that samples a ratio of traces based on the trace ID.
+	OTELTracesSamplerTraceIDRatio OTELTracesSamplerType = "traceidratio"
+	// OTELTracesSamplerParentBasedAlwaysOn represents an enum that respects the parent span's sampling decision
+	// and samples every root span.
+	OTELTracesSamplerParentBasedAlwaysOn OTELTracesSamplerType = "parentbased_always_on"
+	// OTELTracesSamplerParentBasedAlwaysOff represents an enum that respects the parent span's sampling decision
+	// and drops every root span.
+	OTELTracesSamplerParentBasedAlwaysOff OTELTracesSamplerType = "parentbased_always_off"
+	// OTELTracesSamplerParentBasedTraceIDRatio represents an enum that respects the parent span's sampling decision
+	// and samples a ratio of root spans based on the trace ID.
+	OTELTracesSamplerParentBasedTraceIDRatio OTELTracesSamplerType = "parentbased_traceidratio"
+)
+
+// OTELPropagatorType defines the type of OpenTelemetry context propagator.
+type OTELPropagatorType string
+
+const (
+	// OTELPropagatorNone represents an enum that disables context propagation.
+	OTELPropagatorNone OTELPropagatorType = "none"
+	// OTELPropagatorTraceContext represents an enum of the W3C Trace Context propagator.
+	OTELPropagatorTraceContext OTELPropagatorType = "tracecontext"
+	// OTELPropagatorBaggage represents an enum of the W3C Baggage propagator.
+	OTELPropagatorBaggage OTELPropagatorType = "baggage"
+	// OTELPropagatorB3 represents an enum of the B3 single-header propagator.
+	OTELPropagatorB3 OTELPropagatorType = "b3"
+	// OTELPropagatorB3Multi represents an enum of the B3 multi-header propagator.
+	OTELPropagatorB3Multi OTELPropagatorType = "b3multi"
+	// OTELPropagatorJaeger represents an enum of the Jaeger uber-trace-id header propagator.
+	OTELPropagatorJaeger OTELPropagatorType = "jaeger"
+)
+
+var defaultPropagators = []OTELPropagatorType{
+	OTELPropagatorTraceContext,
+	OTELPropagatorB3Multi,
+}
+
+// OTELResourceDetectorType defines the type of detector that adds attributes to the OpenTelemetry resource.
+type OTELResourceDetectorType string
+
+const (
+	// OTELResourceDetectorNone represents an enum that disables resource detection.
+	OTELResourceDetectorNone OTELResourceDetectorType = "none"
+	// OTELResourceDetectorEnv represents an enum that detects attributes
+	// from the OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME environment variables.
+	OTELResourceDetectorEnv OTELResourceDetectorType = "env"
+	// OTELResourceDetectorHost represents an enum that detects the host name, host architecture and OS type.
+	OTELResourceDetectorHost OTELResourceDetectorType = "host"
+	// OTELResourceDetectorProcess represents an enum that detects the process ID, executable and Go runtime.
+	OTELResourceDetectorProcess OTELResourceDetectorType = "process"
+	// OTELResourceDetectorContainer represents an enum that detects the container ID from cgroup files.
+	OTELResourceDetectorContainer OTELResourceDetectorType = "container"
+	// OTELResourceDetectorK8s represents an enum that detects the Kubernetes pod, namespace and node
+	// from environment variables that are exposed by the downward API.
+	OTELResourceDetectorK8s OTELResourceDetectorType = "k8s"
+	// OTELResourceDetectorService represents an enum that generates a random service instance ID per process.
+	OTELResourceDetectorService OTELResourceDetectorType = "service"
+	// OTELResourceDetectorDeployment represents an enum that detects the deployment environment name
+	// from the DEPLOYMENT_ENVIRONMENT environment variable.
+	OTELResourceDetectorDeployment OTELResourceDetectorType = "deployment"
+)
+
+var defaultResourceDetectors = []OTELResourceDetectorType{
+	OTELResourceDetectorEnv,
+	OTELResourceDetectorHost,
+	OTELResourceDetectorProcess,
+	OTELResourceDetectorContainer,
+	OTELResourceDetectorK8s,
+	OTELResourceDetectorService,
+	OTELResourceDetectorDeployment,
+}
+
+// OTELMetricsExemplarFilterType defines the filter of measurements that are sampled as exemplars.
+type OTELMetricsExemplarFilterType string
+
+const (
+	// OTELMetricsExemplarFilterTraceBased represents an enum that samples measurements
+	// that are recorded in the context of a sampled span.
+	OTELMetricsExemplarFilterTraceBased OTELMetricsExemplarFilterType = "trace_based"
+	// OTELMetricsExemplarFilterAlwaysOn represents an enum that samples all measurements.
+	OTELMetricsExemplarFilterAlwaysOn OTELMetricsExemplarFilterType = "always_on"
+	// OTELMetricsExemplarFilterAlwaysOff represents an enum that disables exemplars.
+	OTELMetricsExemplarFilterAlwaysOff OTELMetricsExemplarFilterType = "always_off"
+)
+
+// PrometheusTranslationStrategy defines how OpenTelemetry metric and attribute names are translated to Prometheus names.
+type PrometheusTranslationStrategy string
+
+const (
+	// PrometheusTranslationUnderscoreEscapingWithSuffixes represents an enum that escapes invalid characters
+	// to underscores and appends unit and type suffixes, e.g. http_server_request_duration_seconds.
+	PrometheusTranslationUnderscoreEscapingWithSuffixes PrometheusTranslationStrategy = "UnderscoreEscapingWithSuffixes"
+	// PrometheusTranslationUnderscoreEscapingWithoutSuffixes represents an enum that escapes invalid characters
+	// to underscores without unit and type suffixes.
+	PrometheusTranslationUnderscoreEscapingWithoutSuffixes PrometheusTranslationStrategy = "UnderscoreEscapingWithoutSuffixes"
+	// PrometheusTranslationNoUTF8EscapingWithSuffixes represents an enum that keeps UTF-8 names
+	// and appends unit and type suffixes.
+	PrometheusTranslationNoUTF8EscapingWithSuffixes PrometheusTranslationStrategy = "NoUTF8EscapingWithSuffixes"
+	// PrometheusTranslationNoTranslation represents an enum that keeps OpenTelemetry names as they are.
+	PrometheusTranslationNoTranslation PrometheusTranslationStrategy = "NoTranslation"
 )
 
+// StatsdFlavor defines the line format of the StatsD metrics exporter.
+type StatsdFlavor string
+
+const (
+	// StatsdFlavorDogStatsd represents an enum of the DogStatsD format that sends attributes as tags.
+	StatsdFlavorDogStatsd StatsdFlavor = "dogstatsd"
+	// StatsdFlavorStatsd represents an enum of the plain StatsD format that does not support tags.
+	StatsdFlavorStatsd StatsdFlavor = "statsd"
+)
+
+// OTLPMetricsTemporalityPreference defines the aggregation temporality of the OTLP metrics exporter.
+type OTLPMetricsTemporalityPreference string
+
+const (
+	// OTLPMetricsTemporalityCumulative represents an enum that uses the cumulative temporality for all instruments.
+	OTLPMetricsTemporalityCumulative OTLPMetricsTemporalityPreference = "cumulative"
+	// OTLPMetricsTemporalityDelta represents an enum that uses the delta temporality
+	// for counters and histograms, and the cumulative temporality for up-down counters.
+	OTLPMetricsTemporalityDelta OTLPMetricsTemporalityPreference = "delta"
+	// OTLPMetricsTemporalityLowMemory represents an enum that uses the delta temporality
+	// for synchronous counters and histograms, and the cumulative temporality for other instruments.
+	OTLPMetricsTemporalityLowMemory OTLPMetricsTemporalityPreference = "lowmemory"
+)
+
+// OTELMetricAggregationType defines the aggregation type of a metric view.
+type OTELMetricAggregationType string
+
+const (
+	// OTELMetricAggregationDefault represents an enum that uses the default aggregation of the instrument kind.
+	OTELMetricAggregationDefault OTELMetricAggregationType = "default"
+	// OTELMetricAggregationDrop represents an enum that drops all measurements of the instrument.
+	OTELMetricAggregationDrop OTELMetricAggregationType = "drop"
+	// OTELMetricAggregationSum represents an enum that aggregates measurements as a sum.
+	OTELMetricAggregationSum OTELMetricAggregationType = "sum"
+	// OTELMetricAggregationLastValue represents an enum that keeps the last measurement.
+	OTELMetricAggregationLastValue OTELMetricAggregationType = "last_value"
+	// OTELMetricAggregationExplicitBucketHistogram represents an enum that aggregates measurements
+	// into a histogram with explicit bucket boundaries.
+	OTELMetricAggregationExplicitBucketHistogram OTELMetricAggregationType = "explicit_bucket_histogram"
+	// OTELMetricAggregationBase2ExponentialBucketHistogram represents an enum that aggregates measurements
+	// into a histogram with base-2 exponential buckets.
+	OTELMetricAggregationBase2ExponentialBucketHistogram OTELMetricAggregationType = "base2_exponential_bucket_histogram"
+)
+
+// MetricViewConfig defines a view that customizes the metric stream of matched instruments.
+type MetricViewConfig struct {
+	// Name of instruments to be matched. Accept the * and ? wildcards.
+	InstrumentName string `json:"instrumentName" yaml:"instrumentName" help:"Name of instruments to be matched. Accept the * and ? wildcards"`
+	// Name of the meter of instruments to be matched. Match all meters if empty.
+	MeterName string `json:"meterName,omitempty" yaml:"meterName,omitempty" help:"Name of the meter of instruments to be matched. Match all meters if empty"`
+	// New name of the metric stream. The instrument name must not contain wildcards.
+	Name string `json:"name,omitempty" yaml:"name,omitempty" help:"New name of the metric stream. The instrument name must not contain wildcards"`
+	// New description of the metric stream.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" help:"New description of the metric stream"`
+	// Aggregation of the metric stream. Default is the default aggregation of the instrument kind,
+	// or explicit_bucket_histogram if buckets are set.
+	Aggregation OTELMetricAggregationType `json:"aggregation,omitempty" yaml:"aggregation,omitempty" enum:"default,drop,sum,last_value,explicit_bucket_histogram,base2_exponential_bucket_histogram" jsonschema:"enum=default,enum=drop,enum=sum,enum=last_value,enum=explicit_bucket_histogram,enum=base2_exponential_bucket_histogram" help:"Aggregation of the metric stream"`
+	// Increasing bucket boundaries of the explicit bucket histogram aggregation.
+	// Required if the aggregation is explicit_bucket_histogram.
+	Buckets []float64 `json:"buckets,omitempty" yaml:"buckets,omitempty" help:"Increasing bucket boundaries of the explicit bucket histogram aggregation. Required if the aggregation is explicit_bucket_histogram"`
+	// Attribute keys to be kept in the metric stream. All attributes are kept if empty.
+	AttributeKeys []string `json:"attributeKeys,omitempty" yaml:"attributeKeys,omitempty" help:"Attribute keys to be kept in the metric stream. All attributes are kept if empty"`
+	// Drop all measurements of matched instruments. It is a shortcut of the drop aggregation.
+	Drop bool `json:"drop,omitempty" yaml:"drop,omitempty" help:"Drop all measurements of matched instruments"`
+}
+
 var (
-	errInvalidOTLPCompressionType = errors.New(
+	// ErrInvalidOTLPCompressionType occurs when the OTLP compression type is not none or gzip.
+	ErrInvalidOTLPCompressionType = errors.New(
 		"invalid OTLP compression type, accept none, gzip only",
 	)
-	errInvalidOTELMetricExporterType = errors.New("invalid OTEL metrics exporter type")
-	errInvalidOTLPProtocol           = errors.New("invalid OTLP protocol")
-	errMetricsOTLPEndpointRequired   = errors.New("OTLP endpoint is required for metrics exporter")
+	// ErrInvalidOTELMetricExporterType occurs when the metrics exporter type is not supported.
+	ErrInvalidOTELMetricExporterType = errors.New("invalid OTEL metrics exporter type")
+	// ErrInvalidOTELLogsExporterType occurs when the logs exporter type is not supported.
+	ErrInvalidOTELLogsExporterType = errors.New("invalid OTEL logs exporter type")
+	// ErrInvalidOTELTracesExporterType occurs when the traces exporter type is not supported.
+	ErrInvalidOTELTracesExporterType = errors.New("invalid OTEL traces exporter type")
+	// ErrInvalidOTLPProtocol occurs when the OTLP protocol is not grpc or http/protobuf.
+	ErrInvalidOTLPProtocol = errors.New("invalid OTLP protocol")
+	// ErrInvalidOTLPEndpoint occurs when the OTLP endpoint is not a valid URL.
+	ErrInvalidOTLPEndpoint = errors.New("invalid OTLP endpoint")
+	// ErrMetricsOTLPEndpointRequired occurs when the otlp metrics exporter is enabled without an endpoint.
+	ErrMetricsOTLPEndpointRequired = errors.New("OTLP endpoint is required for metrics exporter")
+	// ErrInvalidOTELTracesSamplerType occurs when the traces sampler type is not supported.
+	ErrInvalidOTELTracesSamplerType = errors.New("invalid OTEL traces sampler type")
+	// ErrInvalidOTELTracesSamplerArg occurs when the sampling probability is out of range.
+	ErrInvalidOTELTracesSamplerArg = errors.New(
+		"invalid OTEL traces sampler argument, must be in range [0, 1]",
+	)
+	// ErrInvalidOTLPCertificate occurs when the OTLP CA certificate file does not contain any valid PEM certificate.
+	ErrInvalidOTLPCertificate = errors.New("invalid OTLP certificate")
+	// ErrOTLPClientKeyPairRequired occurs when only one of the OTLP client certificate and client key is set.
+	ErrOTLPClientKeyPairRequired = errors.New(
+		"both OTLP client certificate and client key are required for mTLS",
+	)
+	// ErrInvalidOTELPropagatorType occurs when the propagator type is not supported.
+	ErrInvalidOTELPropagatorType = errors.New("invalid OTEL propagator type")
+	// ErrInvalidOTELMetricsExemplarFilterType occurs when the exemplar filter type is not supported.
+	ErrInvalidOTELMetricsExemplarFilterType = errors.New("invalid OTEL metrics exemplar filter type")
+	// ErrInvalidOTLPMetricsTemporalityPreference occurs when the temporality preference of metrics is not supported.
+	ErrInvalidOTLPMetricsTemporalityPreference = errors.New("invalid OTLP metrics temporality preference")
+	// ErrInvalidOTELMetricAggregationType occurs when the aggregation type of a metric view
+	// or the default histogram aggregation is not supported.
+	ErrInvalidOTELMetricAggregationType = errors.New("invalid OTEL metric aggregation type")
+	// ErrInvalidMetricView occurs when a metric view is misconfigured,
+	// e.g. the instrument name is empty or buckets are not increasing.
+	ErrInvalidMetricView = errors.New("invalid metric view")
+	// ErrInvalidOTELResourceDetectorType occurs when the resource detector type is not supported.
+	ErrInvalidOTELResourceDetectorType = errors.New("invalid OTEL resource detector type")
+	// ErrInvalidPrometheusTranslationStrategy occurs when the Prometheus translation strategy is not supported.
+	ErrInvalidPrometheusTranslationStrategy = errors.New("invalid Prometheus translation strategy")
+	// ErrPushgatewayURLRequired occurs when the pushgateway metrics exporter is enabled without a URL.
+	ErrPushgatewayURLRequired = errors.New("Pushgateway URL is required for metrics exporter")
+	// ErrPushgatewayJobRequired occurs when the pushgateway metrics exporter is enabled without a job or service name.
+	ErrPushgatewayJobRequired = errors.New("Pushgateway job or service name is required for metrics exporter")
+	// ErrInvalidPushgatewayURL occurs when the Pushgateway URL is not a valid HTTP URL.
+	ErrInvalidPushgatewayURL = errors.New("invalid Pushgateway URL")
+	// ErrInvalidStatsdAddress occurs when the address of the StatsD agent is not in the host:port format.
+	ErrInvalidStatsdAddress = errors.New("invalid StatsD address, must be in the host:port format")
+	// ErrUnsupportedRemoteWriteAggregation occurs when a metric view aggregates to exponential histograms
+	// that the Prometheus remote-write exporter cannot send.
+	ErrUnsupportedRemoteWriteAggregation = errors.New(
+		"exponential histograms are not supported by the Prometheus remote-write metrics exporter",
+	)
+	// ErrInvalidStatsdFlavor occurs when the line format of the StatsD metrics exporter is not supported.
+	ErrInvalidStatsdFlavor = errors.New("invalid StatsD flavor")
+	// ErrUnsupportedDeclarativeFileFormat occurs when the file_format of the declarative configuration is not supported.
+	ErrUnsupportedDeclarativeFileFormat = errors.New("unsupported declarative configuration file format")
+	// ErrUnsupportedDeclarativeConfig occurs when the declarative configuration uses an option
+	// that cannot be mapped to OTLPConfig.
+	ErrUnsupportedDeclarativeConfig = errors.New("unsupported declarative configuration")
+	// ErrInvalidPrometheusPort occurs when the Prometheus port is out of range.
+	ErrInvalidPrometheusPort = errors.New("invalid Prometheus port, must be in range [1, 65535]")
 )
 
 // OTLPConfig contains configuration for OpenTelemetry exporter.
 type OTLPConfig struct {
 	// OpenTelemetry service name.
 	ServiceName string `json:"serviceName,omitempty" yaml:"serviceName,omitempty" env:"OTEL_SERVICE_NAME" help:"OpenTelemetry service name."`
+	// Namespace of the service, e.g. the team or product that owns the service.
+	// Takes precedence over service.namespace of resourceAttributes and OTEL_RESOURCE_ATTRIBUTES.
+	ServiceNamespace string `json:"serviceNamespace,omitempty" yaml:"serviceNamespace,omitempty" help:"Namespace of the service. Takes precedence over service.namespace of resource attributes"`
+	// Unique ID of the service instance. Takes precedence over service.instance.id of resourceAttributes and OTEL_RESOURCE_ATTRIBUTES.
+	// Default is a random UUID that is stable during the process lifetime if the service detector is enabled.
+	ServiceInstanceID string `json:"serviceInstanceId,omitempty" yaml:"serviceInstanceId,omitempty" help:"Unique ID of the service instance. Default is a random UUID per process"`
+	// Key-value pairs to be added to the resource of all signals.
+	// Take precedence over detected attributes and OTEL_RESOURCE_ATTRIBUTES.
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty" yaml:"resourceAttributes,omitempty" help:"Key-value pairs to be added to the resource. Take precedence over detected attributes and OTEL_RESOURCE_ATTRIBUTES"`
+	// Disable all exporters of the SDK. The trace context is still propagated.
+	SdkDisabled *bool `json:"sdkDisabled,omitempty" yaml:"sdkDisabled,omitempty" env:"OTEL_SDK_DISABLED" help:"Disable all exporters of the SDK. The trace context is still propagated"`
 	// OTLP receiver endpoint that is set as default for all types.
 	OtlpEndpoint string `json:"otlpEndpoint,omitempty" yaml:"otlpEndpoint,omitempty" env:"OTEL_EXPORTER_OTLP_ENDPOINT" help:"OTLP receiver endpoint that is set as default for all types."`
 	// OTLP receiver endpoint for traces exporter.
 	OtlpTracesEndpoint string `json:"otlpTracesEndpoint,omitempty" yaml:"otlpTracesEndpoint,omitempty" env:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT" help:"OTLP receiver endpoint for traces."`
 	// OTLP receiver endpoint for metrics exporter.
+	// It is the full remote-write URL of the prometheusremotewrite exporter, e.g. http://prometheus:9090/api/v1/write.
 	OtlpMetricsEndpoint string `json:"otlpMetricsEndpoint,omitempty" yaml:"otlpMetricsEndpoint,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT" help:"OTLP receiver endpoint for metrics."`
 	// OTLP receiver endpoint for logs exporter.
 	OtlpLogsEndpoint string `json:"otlpLogsEndpoint,omitempty" yaml:"otlpLogsEndpoint,omitempty" env:"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT" help:"OTLP receiver endpoint for logs."`
@@ -89,14 +378,141 @@ type OTLPConfig struct {
 	OtlpMetricsCompression OTLPCompressionType `json:"otlpMetricsCompression,omitempty" yaml:"otlpMetricsCompression,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_COMPRESSION" enum:"none,gzip," default:"" jsonschema:"enum=none,enum=gzip" help:"Enable compression for OTLP metrics exporter. Accept: none, gzip"`
 	// Enable compression for OTLP logs exporter. Accept: none, gzip
 	OtlpLogsCompression OTLPCompressionType `json:"otlpLogsCompression,omitempty" yaml:"otlpLogsCompression,omitempty" env:"OTEL_EXPORTER_OTLP_LOGS_COMPRESSION" enum:"none,gzip," default:"" jsonschema:"enum=none,enum=gzip" help:"Enable compression for OTLP logs exporter. Accept: none, gzip"`
-	// Metrics export type. Accept: none, otlp, prometheus
-	MetricsExporter OTELMetricsExporterType `json:"metricsExporter,omitempty" yaml:"metricsExporter,omitempty" env:"OTEL_METRICS_EXPORTER" default:"none" enum:"none,otlp,prometheus" jsonschema:"enum=none,enum=otlp,enum=prometheus" help:"Metrics export type. Accept: none, otlp, prometheus"`
-	// Logs export type. Accept: none, otlp
-	LogsExporter OTELLogsExporterType `json:"logsExporter,omitempty" yaml:"logsExporter,omitempty" env:"OTEL_LOGS_EXPORTER" default:"none" enum:"none,otlp" jsonschema:"enum=none,enum=otlp" help:"Logs export type. Accept: none, otlp"`
+	// Key-value pairs to be used as headers associated with OTLP requests of all exporters.
+	OtlpHeaders map[string]string `json:"otlpHeaders,omitempty" yaml:"otlpHeaders,omitempty" env:"OTEL_EXPORTER_OTLP_HEADERS" envKeyValSeparator:"=" mapsep:"," help:"Key-value pairs to be used as headers associated with OTLP requests of all exporters"`
+	// Key-value pairs to be used as headers associated with OTLP traces requests. Merged with otlpHeaders.
+	OtlpTracesHeaders map[string]string `json:"otlpTracesHeaders,omitempty" yaml:"otlpTracesHeaders,omitempty" env:"OTEL_EXPORTER_OTLP_TRACES_HEADERS" envKeyValSeparator:"=" mapsep:"," help:"Key-value pairs to be used as headers associated with OTLP traces requests"`
+	// Key-value pairs to be used as headers associated with OTLP metrics requests. Merged with otlpHeaders.
+	OtlpMetricsHeaders map[string]string `json:"otlpMetricsHeaders,omitempty" yaml:"otlpMetricsHeaders,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_HEADERS" envKeyValSeparator:"=" mapsep:"," help:"Key-value pairs to be used as headers associated with OTLP metrics requests"`
+	// Key-value pairs to be used as headers associated with OTLP logs requests. Merged with otlpHeaders.
+	OtlpLogsHeaders map[string]string `json:"otlpLogsHeaders,omitempty" yaml:"otlpLogsHeaders,omitempty" env:"OTEL_EXPORTER_OTLP_LOGS_HEADERS" envKeyValSeparator:"=" mapsep:"," help:"Key-value pairs to be used as headers associated with OTLP logs requests"`
+	// Maximum time in milliseconds the OTLP exporters wait for each batch export. Default is 10000.
+	OtlpTimeout *uint `json:"otlpTimeout,omitempty" yaml:"otlpTimeout,omitempty" env:"OTEL_EXPORTER_OTLP_TIMEOUT" help:"Maximum time in milliseconds the OTLP exporters wait for each batch export. Default is 10000"`
+	// Maximum time in milliseconds the OTLP traces exporter waits for each batch export.
+	OtlpTracesTimeout *uint `json:"otlpTracesTimeout,omitempty" yaml:"otlpTracesTimeout,omitempty" env:"OTEL_EXPORTER_OTLP_TRACES_TIMEOUT" help:"Maximum time in milliseconds the OTLP traces exporter waits for each batch export"`
+	// Maximum time in milliseconds the OTLP metrics exporter waits for each batch export.
+	OtlpMetricsTimeout *uint `json:"otlpMetricsTimeout,omitempty" yaml:"otlpMetricsTimeout,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_TIMEOUT" help:"Maximum time in milliseconds the OTLP metrics exporter waits for each batch export"`
+	// Maximum time in milliseconds the OTLP logs exporter waits for each batch export.
+	OtlpLogsTimeout *uint `json:"otlpLogsTimeout,omitempty" yaml:"otlpLogsTimeout,omitempty" env:"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT" help:"Maximum time in milliseconds the OTLP logs exporter waits for each batch export"`
+	// Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of all exporters.
+	OtlpCertificate string `json:"otlpCertificate,omitempty" yaml:"otlpCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_CERTIFICATE" help:"Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of all exporters"`
+	// Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of the traces exporter.
+	OtlpTracesCertificate string `json:"otlpTracesCertificate,omitempty" yaml:"otlpTracesCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE" help:"Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of the traces exporter"`
+	// Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of the metrics exporter.
+	OtlpMetricsCertificate string `json:"otlpMetricsCertificate,omitempty" yaml:"otlpMetricsCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE" help:"Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of the metrics exporter"`
+	// Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of the logs exporter.
+	OtlpLogsCertificate string `json:"otlpLogsCertificate,omitempty" yaml:"otlpLogsCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_LOGS_CERTIFICATE" help:"Path to the PEM-encoded trusted certificate used to verify the server's TLS credentials of the logs exporter"`
+	// Path to the PEM-encoded client certificate used for mTLS of all exporters.
+	OtlpClientCertificate string `json:"otlpClientCertificate,omitempty" yaml:"otlpClientCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE" help:"Path to the PEM-encoded client certificate used for mTLS of all exporters"`
+	// Path to the PEM-encoded client certificate used for mTLS of the traces exporter.
+	OtlpTracesClientCertificate string `json:"otlpTracesClientCertificate,omitempty" yaml:"otlpTracesClientCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE" help:"Path to the PEM-encoded client certificate used for mTLS of the traces exporter"`
+	// Path to the PEM-encoded client certificate used for mTLS of the metrics exporter.
+	OtlpMetricsClientCertificate string `json:"otlpMetricsClientCertificate,omitempty" yaml:"otlpMetricsClientCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_CLIENT_CERTIFICATE" help:"Path to the PEM-encoded client certificate used for mTLS of the metrics exporter"`
+	// Path to the PEM-encoded client certificate used for mTLS of the logs exporter.
+	OtlpLogsClientCertificate string `json:"otlpLogsClientCertificate,omitempty" yaml:"otlpLogsClientCertificate,omitempty" env:"OTEL_EXPORTER_OTLP_LOGS_CLIENT_CERTIFICATE" help:"Path to the PEM-encoded client certificate used for mTLS of the logs exporter"`
+	// Path to the PEM-encoded client private key used for mTLS of all exporters.
+	OtlpClientKey string `json:"otlpClientKey,omitempty" yaml:"otlpClientKey,omitempty" env:"OTEL_EXPORTER_OTLP_CLIENT_KEY" help:"Path to the PEM-encoded client private key used for mTLS of all exporters"`
+	// Path to the PEM-encoded client private key used for mTLS of the traces exporter.
+	OtlpTracesClientKey string `json:"otlpTracesClientKey,omitempty" yaml:"otlpTracesClientKey,omitempty" env:"OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY" help:"Path to the PEM-encoded client private key used for mTLS of the traces exporter"`
+	// Path to the PEM-encoded client private key used for mTLS of the metrics exporter.
+	OtlpMetricsClientKey string `json:"otlpMetricsClientKey,omitempty" yaml:"otlpMetricsClientKey,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_CLIENT_KEY" help:"Path to the PEM-encoded client private key used for mTLS of the metrics exporter"`
+	// Path to the PEM-encoded client private key used for mTLS of the logs exporter.
+	OtlpLogsClientKey string `json:"otlpLogsClientKey,omitempty" yaml:"otlpLogsClientKey,omitempty" env:"OTEL_EXPORTER_OTLP_LOGS_CLIENT_KEY" help:"Path to the PEM-encoded client private key used for mTLS of the logs exporter"`
+	// Aggregation temporality of the OTLP metrics exporter. Accept: cumulative, delta, lowmemory. Default is cumulative.
+	OtlpMetricsTemporalityPreference OTLPMetricsTemporalityPreference `json:"otlpMetricsTemporalityPreference,omitempty" yaml:"otlpMetricsTemporalityPreference,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE" default:"cumulative" enum:"cumulative,delta,lowmemory" jsonschema:"enum=cumulative,enum=delta,enum=lowmemory" help:"Aggregation temporality of the OTLP metrics exporter. Accept: cumulative, delta, lowmemory. Default is cumulative"`
+	// Default aggregation of histogram instruments of the OTLP metrics exporter.
+	// Accept: explicit_bucket_histogram, base2_exponential_bucket_histogram. Default is explicit_bucket_histogram.
+	OtlpMetricsDefaultHistogramAggregation OTELMetricAggregationType `json:"otlpMetricsDefaultHistogramAggregation,omitempty" yaml:"otlpMetricsDefaultHistogramAggregation,omitempty" env:"OTEL_EXPORTER_OTLP_METRICS_DEFAULT_HISTOGRAM_AGGREGATION" default:"explicit_bucket_histogram" enum:"explicit_bucket_histogram,base2_exponential_bucket_histogram" jsonschema:"enum=explicit_bucket_histogram,enum=base2_exponential_bucket_histogram" help:"Default aggregation of histogram instruments of the OTLP metrics exporter. Accept: explicit_bucket_histogram, base2_exponential_bucket_histogram. Default is explicit_bucket_histogram"`
+	// Traces export type. Accept: none, otlp, console, file. Default is otlp.
+	// The otlp exporter is only enabled if the traces endpoint is set.
+	TracesExporter OTELTracesExporterType `json:"tracesExporter,omitempty" yaml:"tracesExporter,omitempty" env:"OTEL_TRACES_EXPORTER" default:"otlp" enum:"none,otlp,console,file" jsonschema:"enum=none,enum=otlp,enum=console,enum=file" help:"Traces export type. Accept: none, otlp, console, file. Default is otlp"`
+	// Metrics export type. Accept: none, otlp, prometheus, console, file, pushgateway, prometheusremotewrite, statsd
+	MetricsExporter OTELMetricsExporterType `json:"metricsExporter,omitempty" yaml:"metricsExporter,omitempty" env:"OTEL_METRICS_EXPORTER" default:"none" enum:"none,otlp,prometheus,console,file,pushgateway,prometheusremotewrite,statsd" jsonschema:"enum=none,enum=otlp,enum=prometheus,enum=console,enum=file,enum=pushgateway,enum=prometheusremotewrite,enum=statsd" help:"Metrics export type. Accept: none, otlp, prometheus, console, file, pushgateway, prometheusremotewrite, statsd"`
+	// Logs export type. Accept: none, otlp, console, file
+	LogsExporter OTELLogsExporterType `json:"logsExporter,omitempty" yaml:"logsExporter,omitempty" env:"OTEL_LOGS_EXPORTER" default:"none" enum:"none,otlp,console,file" jsonschema:"enum=none,enum=otlp,enum=console,enum=file" help:"Logs export type. Accept: none, otlp, console, file"`
+	// Path of the file that the file traces exporter writes to. Default is traces.jsonl.
+	TracesFilePath string `json:"tracesFilePath,omitempty" yaml:"tracesFilePath,omitempty" env:"OTEL_EXPORTER_FILE_TRACES_PATH" help:"Path of the file that the file traces exporter writes to. Default is traces.jsonl"`
+	// Path of the file that the file metrics exporter writes to. Default is metrics.jsonl.
+	MetricsFilePath string `json:"metricsFilePath,omitempty" yaml:"metricsFilePath,omitempty" env:"OTEL_EXPORTER_FILE_METRICS_PATH" help:"Path of the file that the file metrics exporter writes to. Default is metrics.jsonl"`
+	// Path of the file that the file logs exporter writes to. Default is logs.jsonl.
+	LogsFilePath string `json:"logsFilePath,omitempty" yaml:"logsFilePath,omitempty" env:"OTEL_EXPORTER_FILE_LOGS_PATH" help:"Path of the file that the file logs exporter writes to. Default is logs.jsonl"`
 	// Prometheus port for the Prometheus HTTP server. Use /metrics endpoint of the connector server if empty.
-	PrometheusPort *uint `json:"prometheusPort,omitempty" yaml:"prometheusPort,omitempty" env:"OTEL_EXPORTER_PROMETHEUS_PORT" jsonschema:"minimum=1000,maximum=65535" help:"Prometheus port for the Prometheus HTTP server. Use /metrics endpoint of the connector server if empty"`
-	// Disable internal Go and process metrics (prometheus exporter only).
-	DisableGoMetrics *bool `json:"disableGoMetrics,omitempty" yaml:"disableGoMetrics,omitempty" help:"Disable internal Go and process metrics"`
+	PrometheusPort *uint `json:"prometheusPort,omitempty" yaml:"prometheusPort,omitempty" env:"OTEL_EXPORTER_PROMETHEUS_PORT" jsonschema:"minimum=1,maximum=65535" help:"Prometheus port for the Prometheus HTTP server. Use /metrics endpoint of the connector server if empty"`
+	// Prefix of Prometheus metric names, e.g. myapp_http_server_request_duration_seconds. Metadata metrics such as target_info are not prefixed.
+	PrometheusNamespace string `json:"prometheusNamespace,omitempty" yaml:"prometheusNamespace,omitempty" env:"OTEL_EXPORTER_PROMETHEUS_NAMESPACE" help:"Prefix of Prometheus metric names. Metadata metrics such as target_info are not prefixed"`
+	// Strategy of translating metric names to Prometheus names, including unit and type suffixes.
+	// Accept: UnderscoreEscapingWithSuffixes, UnderscoreEscapingWithoutSuffixes, NoUTF8EscapingWithSuffixes, NoTranslation.
+	// Default is UnderscoreEscapingWithSuffixes.
+	PrometheusTranslationStrategy PrometheusTranslationStrategy `json:"prometheusTranslationStrategy,omitempty" yaml:"prometheusTranslationStrategy,omitempty" env:"OTEL_EXPORTER_PROMETHEUS_TRANSLATION_STRATEGY" default:"UnderscoreEscapingWithSuffixes" enum:"UnderscoreEscapingWithSuffixes,UnderscoreEscapingWithoutSuffixes,NoUTF8EscapingWithSuffixes,NoTranslation" jsonschema:"enum=UnderscoreEscapingWithSuffixes,enum=UnderscoreEscapingWithoutSuffixes,enum=NoUTF8EscapingWithSuffixes,enum=NoTranslation" help:"Strategy of translating metric names to Prometheus names, including unit and type suffixes. Default is UnderscoreEscapingWithSuffixes"`
+	// Disable the otel_scope_* labels of the instrumentation scope on all Prometheus metrics.
+	PrometheusWithoutScopeInfo *bool `json:"prometheusWithoutScopeInfo,omitempty" yaml:"prometheusWithoutScopeInfo,omitempty" env:"OTEL_EXPORTER_PROMETHEUS_WITHOUT_SCOPE_INFO" help:"Disable the otel_scope_* labels of the instrumentation scope on all Prometheus metrics"`
+	// Disable the target_info metric that contains resource attributes.
+	PrometheusWithoutTargetInfo *bool `json:"prometheusWithoutTargetInfo,omitempty" yaml:"prometheusWithoutTargetInfo,omitempty" env:"OTEL_EXPORTER_PROMETHEUS_WITHOUT_TARGET_INFO" help:"Disable the target_info metric that contains resource attributes"`
+	// URL of the Prometheus Pushgateway that the pushgateway metrics exporter pushes to, e.g. http://pushgateway:9091.
+	PushgatewayURL string `json:"pushgatewayUrl,omitempty" yaml:"pushgatewayUrl,omitempty" env:"OTEL_EXPORTER_PUSHGATEWAY_URL" help:"URL of the Prometheus Pushgateway that the pushgateway metrics exporter pushes to"`
+	// Job label of metrics that are pushed to the Pushgateway. Default is the service name.
+	PushgatewayJob string `json:"pushgatewayJob,omitempty" yaml:"pushgatewayJob,omitempty" env:"OTEL_EXPORTER_PUSHGATEWAY_JOB" help:"Job label of metrics that are pushed to the Pushgateway. Default is the service name"`
+	// Grouping labels of metrics that are pushed to the Pushgateway in addition to the job, e.g. instance=worker-1.
+	PushgatewayGroupingLabels map[string]string `json:"pushgatewayGroupingLabels,omitempty" yaml:"pushgatewayGroupingLabels,omitempty" env:"OTEL_EXPORTER_PUSHGATEWAY_GROUPING_LABELS" envKeyValSeparator:"=" mapsep:"," help:"Grouping labels of metrics that are pushed to the Pushgateway in addition to the job"`
+	// Address of the StatsD agent that the statsd metrics exporter sends UDP lines to. Default is localhost:8125.
+	StatsdAddress string `json:"statsdAddress,omitempty" yaml:"statsdAddress,omitempty" env:"OTEL_EXPORTER_STATSD_ADDRESS" default:"localhost:8125" help:"Address of the StatsD agent that the statsd metrics exporter sends UDP lines to. Default is localhost:8125"`
+	// Line format of the statsd metrics exporter. Accept: dogstatsd, statsd. Default is dogstatsd.
+	// Attributes are sent as tags in the DogStatsD format only.
+	StatsdFlavor StatsdFlavor `json:"statsdFlavor,omitempty" yaml:"statsdFlavor,omitempty" env:"OTEL_EXPORTER_STATSD_FLAVOR" default:"dogstatsd" enum:"dogstatsd,statsd" jsonschema:"enum=dogstatsd,enum=statsd" help:"Line format of the statsd metrics exporter. Accept: dogstatsd, statsd. Default is dogstatsd"`
+	// Interval in milliseconds between two consecutive flushes of the statsd metrics exporter.
+	// Default is the metricsExportInterval value.
+	StatsdFlushInterval *uint `json:"statsdFlushInterval,omitempty" yaml:"statsdFlushInterval,omitempty" env:"OTEL_EXPORTER_STATSD_FLUSH_INTERVAL" jsonschema:"minimum=1" help:"Interval in milliseconds between two consecutive flushes of the statsd metrics exporter. Default is the metricsExportInterval value"`
+	// Sampler to be used for traces. Default is parentbased_always_on.
+	TracesSampler OTELTracesSamplerType `json:"tracesSampler,omitempty" yaml:"tracesSampler,omitempty" env:"OTEL_TRACES_SAMPLER" default:"parentbased_always_on" enum:"always_on,always_off,traceidratio,parentbased_always_on,parentbased_always_off,parentbased_traceidratio" jsonschema:"enum=always_on,enum=always_off,enum=traceidratio,enum=parentbased_always_on,enum=parentbased_always_off,enum=parentbased_traceidratio" help:"Sampler to be used for traces. Default is parentbased_always_on"`
+	// Sampling probability in range [0, 1] for the traceidratio and parentbased_traceidratio samplers. Default is 1.
+	TracesSamplerArg *float64 `json:"tracesSamplerArg,omitempty" yaml:"tracesSamplerArg,omitempty" env:"OTEL_TRACES_SAMPLER_ARG" jsonschema:"minimum=0,maximum=1" help:"Sampling probability in range [0, 1] for the traceidratio and parentbased_traceidratio samplers. Default is 1"`
+	// Maximum queue size of the batch span processor. Default is 2048.
+	TracesMaxQueueSize *uint `json:"tracesMaxQueueSize,omitempty" yaml:"tracesMaxQueueSize,omitempty" env:"OTEL_BSP_MAX_QUEUE_SIZE" jsonschema:"minimum=1" help:"Maximum queue size of the batch span processor. Default is 2048"`
+	// Maximum batch size of every export of the batch span processor. Must be less than or equal to the queue size. Default is 512.
+	TracesMaxExportBatchSize *uint `json:"tracesMaxExportBatchSize,omitempty" yaml:"tracesMaxExportBatchSize,omitempty" env:"OTEL_BSP_MAX_EXPORT_BATCH_SIZE" jsonschema:"minimum=1" help:"Maximum batch size of every export of the batch span processor. Default is 512"`
+	// Maximum time in milliseconds the batch span processor waits for each export. Default is 30000.
+	TracesExportTimeout *uint `json:"tracesExportTimeout,omitempty" yaml:"tracesExportTimeout,omitempty" env:"OTEL_BSP_EXPORT_TIMEOUT" help:"Maximum time in milliseconds the batch span processor waits for each export. Default is 30000"`
+	// Delay interval in milliseconds between two consecutive exports of the batch span processor. Default is 5000.
+	TracesScheduleDelay *uint `json:"tracesScheduleDelay,omitempty" yaml:"tracesScheduleDelay,omitempty" env:"OTEL_BSP_SCHEDULE_DELAY" help:"Delay interval in milliseconds between two consecutive exports of the batch span processor. Default is 5000"`
+	// Maximum queue size of the batch log record processor. Default is 2048.
+	LogsMaxQueueSize *uint `json:"logsMaxQueueSize,omitempty" yaml:"logsMaxQueueSize,omitempty" env:"OTEL_BLRP_MAX_QUEUE_SIZE" jsonschema:"minimum=1" help:"Maximum queue size of the batch log record processor. Default is 2048"`
+	// Maximum batch size of every export of the batch log record processor. Must be less than or equal to the queue size. Default is 512.
+	LogsMaxExportBatchSize *uint `json:"logsMaxExportBatchSize,omitempty" yaml:"logsMaxExportBatchSize,omitempty" env:"OTEL_BLRP_MAX_EXPORT_BATCH_SIZE" jsonschema:"minimum=1" help:"Maximum batch size of every export of the batch log record processor. Default is 512"`
+	// Maximum time in milliseconds the batch log record processor waits for each export. Default is 30000.
+	LogsExportTimeout *uint `json:"logsExportTimeout,omitempty" yaml:"logsExportTimeout,omitempty" env:"OTEL_BLRP_EXPORT_TIMEOUT" help:"Maximum time in milliseconds the batch log record processor waits for each export. Default is 30000"`
+	// Delay interval in milliseconds between two consecutive exports of the batch log record processor. Default is 1000.
+	LogsScheduleDelay *uint `json:"logsScheduleDelay,omitempty" yaml:"logsScheduleDelay,omitempty" env:"OTEL_BLRP_SCHEDULE_DELAY" help:"Delay interval in milliseconds between two consecutive exports of the batch log record processor. Default is 1000"`
+	// Interval in milliseconds between two consecutive exports of the periodic metric reader. Default is 60000.
+	MetricsExportInterval *uint `json:"metricsExportInterval,omitempty" yaml:"metricsExportInterval,omitempty" env:"OTEL_METRIC_EXPORT_INTERVAL" help:"Interval in milliseconds between two consecutive exports of the periodic metric reader. Default is 60000"`
+	// Maximum time in milliseconds the periodic metric reader waits for each export. Default is 30000.
+	MetricsExportTimeout *uint `json:"metricsExportTimeout,omitempty" yaml:"metricsExportTimeout,omitempty" env:"OTEL_METRIC_EXPORT_TIMEOUT" help:"Maximum time in milliseconds the periodic metric reader waits for each export. Default is 30000"`
+	// Filter of measurements that are sampled as exemplars to link metrics to traces.
+	// Accept: trace_based, always_on, always_off. Default is trace_based.
+	MetricsExemplarFilter OTELMetricsExemplarFilterType `json:"metricsExemplarFilter,omitempty" yaml:"metricsExemplarFilter,omitempty" env:"OTEL_METRICS_EXEMPLAR_FILTER" default:"trace_based" enum:"trace_based,always_on,always_off" jsonschema:"enum=trace_based,enum=always_on,enum=always_off" help:"Filter of measurements that are sampled as exemplars. Accept: trace_based, always_on, always_off. Default is trace_based"`
+	// Maximum number of attribute sets of each metric instrument in every collection.
+	// Measurements of excess attribute sets are folded into a single series with the otel.metric.overflow=true attribute.
+	// Default is 2000. Zero or a negative value disables the limit.
+	// The gotel.metric.cardinality_overflows counter reports the number of collections in which each instrument
+	// contained the overflow series. Collections run at the metrics export interval for all exporters.
+	MetricsCardinalityLimit *int `json:"metricsCardinalityLimit,omitempty" yaml:"metricsCardinalityLimit,omitempty" env:"OTEL_METRICS_CARDINALITY_LIMIT" help:"Maximum number of attribute sets of each metric instrument in every collection. Excess attribute sets are folded into the otel.metric.overflow series. Default is 2000"`
+	// Propagators used to inject and extract the trace context across services.
+	// Accept: tracecontext, baggage, b3, b3multi, jaeger, none. Default is tracecontext, b3multi.
+	Propagators []OTELPropagatorType `json:"propagators,omitempty" yaml:"propagators,omitempty" env:"OTEL_PROPAGATORS" envSeparator:"," sep:"," enum:"tracecontext,baggage,b3,b3multi,jaeger,none" jsonschema:"enum=tracecontext,enum=baggage,enum=b3,enum=b3multi,enum=jaeger,enum=none" help:"Propagators used to inject and extract the trace context. Accept: tracecontext, baggage, b3, b3multi, jaeger, none. Default is tracecontext, b3multi"`
+	// Detectors that add attributes to the resource of all signals.
+	// Accept: env, host, process, container, k8s, service, deployment, none. Default is all detectors.
+	ResourceDetectors []OTELResourceDetectorType `json:"resourceDetectors,omitempty" yaml:"resourceDetectors,omitempty" env:"OTEL_RESOURCE_DETECTORS" envSeparator:"," sep:"," enum:"env,host,process,container,k8s,service,deployment,none" jsonschema:"enum=env,enum=host,enum=process,enum=container,enum=k8s,enum=service,enum=deployment,enum=none" help:"Detectors that add attributes to the resource. Accept: env, host, process, container, k8s, service, deployment, none. Default is all detectors"`
+	// Disable Go runtime metrics of all metrics exporters,
+	// and the process collector of the Prometheus registry.
+	DisableGoMetrics *bool `json:"disableGoMetrics,omitempty" yaml:"disableGoMetrics,omitempty" help:"Disable Go runtime and process metrics"`
+	// Views that rename, drop, re-aggregate or filter attributes of metric streams before export.
+	MetricViews []MetricViewConfig `json:"metricViews,omitempty" yaml:"metricViews,omitempty" help:"Views that rename, drop, re-aggregate or filter attributes of metric streams before export"`
+	// Enable process metrics of CPU time, memory, threads, open file descriptors and disk I/O
+	// that are read from /proc. Linux only. The network I/O of system.network.io is also read from /proc/self/net/dev,
+	// and it counts the traffic of the whole network namespace instead of the process.
+	EnableHostMetrics *bool `json:"enableHostMetrics,omitempty" yaml:"enableHostMetrics,omitempty" env:"OTEL_HOST_METRICS_ENABLED" help:"Enable process metrics of CPU time, memory, threads, open file descriptors and disk I/O, and the network I/O of the network namespace, that are read from /proc. Linux only"`
+	// Mount path of the procfs that host metrics are read from, e.g. when /proc is mounted elsewhere in a container.
+	// Default is /proc.
+	HostMetricsProcfsPath string `json:"hostMetricsProcfsPath,omitempty" yaml:"hostMetricsProcfsPath,omitempty" env:"OTEL_HOST_METRICS_PROCFS_PATH" default:"/proc" help:"Mount path of the procfs that host metrics are read from. Default is /proc"`
 }
 
 // GetOTLPProtocol returns the OTLP protocol for OpenTelemetry exporters. Default is grpc.
@@ -171,6 +587,128 @@ func (oc OTLPConfig) GetOTLPLogsCompression() OTLPCompressionType {
 	return oc.GetOTLPCompression()
 }
 
+// GetOTLPMetricsTemporalityPreference returns the temporality preference of the OTLP metrics exporter.
+// Default is cumulative.
+func (oc OTLPConfig) GetOTLPMetricsTemporalityPreference() OTLPMetricsTemporalityPreference {
+	if oc.OtlpMetricsTemporalityPreference == "" {
+		return OTLPMetricsTemporalityCumulative
+	}
+
+	return oc.OtlpMetricsTemporalityPreference
+}
+
+// GetOTLPMetricsDefaultHistogramAggregation returns the default histogram aggregation of the OTLP metrics exporter.
+// Default is explicit_bucket_histogram.
+func (oc OTLPConfig) GetOTLPMetricsDefaultHistogramAggregation() OTELMetricAggregationType {
+	if oc.OtlpMetricsDefaultHistogramAggregation == "" {
+		return OTELMetricAggregationExplicitBucketHistogram
+	}
+
+	return oc.OtlpMetricsDefaultHistogramAggregation
+}
+
+// GetMetricsExemplarFilter returns the exemplar filter of metrics. Default is trace_based.
+func (oc OTLPConfig) GetMetricsExemplarFilter() OTELMetricsExemplarFilterType {
+	if oc.MetricsExemplarFilter == "" {
+		return OTELMetricsExemplarFilterTraceBased
+	}
+
+	return oc.MetricsExemplarFilter
+}
+
+// GetStatsdAddress returns the address of the StatsD agent. Default is localhost:8125.
+func (oc OTLPConfig) GetStatsdAddress() string {
+	return getDefault(oc.StatsdAddress, defaultStatsdAddress)
+}
+
+// GetStatsdFlavor returns the line format of the StatsD metrics exporter. Default is dogstatsd.
+func (oc OTLPConfig) GetStatsdFlavor() StatsdFlavor {
+	return getDefault(oc.StatsdFlavor, StatsdFlavorDogStatsd)
+}
+
+// GetHostMetricsProcfsPath returns the mount path of the procfs that host metrics are read from. Default is /proc.
+func (oc OTLPConfig) GetHostMetricsProcfsPath() string {
+	return getDefault(oc.HostMetricsProcfsPath, defaultProcfsPath)
+}
+
+// GetPrometheusTranslationStrategy returns the strategy of translating metric names to Prometheus names.
+// Default is UnderscoreEscapingWithSuffixes.
+func (oc OTLPConfig) GetPrometheusTranslationStrategy() PrometheusTranslationStrategy {
+	if oc.PrometheusTranslationStrategy == "" {
+		return PrometheusTranslationUnderscoreEscapingWithSuffixes
+	}
+
+	return oc.PrometheusTranslationStrategy
+}
+
+// GetPushgatewayJob returns the job label of metrics that are pushed to the Pushgateway. Default is the service name.
+func (oc OTLPConfig) GetPushgatewayJob() string {
+	return getDefault(oc.PushgatewayJob, oc.ServiceName)
+}
+
+// GetOTLPTracesHeaders returns the headers of OTLP traces requests.
+// Traces headers take precedence over general headers with the same key.
+func (oc OTLPConfig) GetOTLPTracesHeaders() map[string]string {
+	return mergeOTLPHeaders(oc.OtlpHeaders, oc.OtlpTracesHeaders)
+}
+
+// GetOTLPMetricsHeaders returns the headers of OTLP metrics requests.
+// Metrics headers take precedence over general headers with the same key.
+func (oc OTLPConfig) GetOTLPMetricsHeaders() map[string]string {
+	return mergeOTLPHeaders(oc.OtlpHeaders, oc.OtlpMetricsHeaders)
+}
+
+// GetOTLPLogsHeaders returns the headers of OTLP logs requests.
+// Logs headers take precedence over general headers with the same key.
+func (oc OTLPConfig) GetOTLPLogsHeaders() map[string]string {
+	return mergeOTLPHeaders(oc.OtlpHeaders, oc.OtlpLogsHeaders)
+}
+
+// GetOTLPTimeout returns the export timeout of OTLP exporters. Default is 10 seconds.
+func (oc OTLPConfig) GetOTLPTimeout() time.Duration {
+	if oc.OtlpTimeout == nil {
+		return otlpDefaultTimeout
+	}
+
+	return time.Duration(*oc.OtlpTimeout) * time.Millisecond
+}
+
+// GetOTLPTracesTimeout returns the export timeout of the OTLP traces exporter. Default is the otlpTimeout value.
+func (oc OTLPConfig) GetOTLPTracesTimeout() time.Duration {
+	if oc.OtlpTracesTimeout != nil {
+		return time.Duration(*oc.OtlpTracesTimeout) * time.Millisecond
+	}
+
+	return oc.GetOTLPTimeout()
+}
+
+// GetOTLPMetricsTimeout returns the export timeout of the OTLP metrics exporter. Default is the otlpTimeout value.
+func (oc OTLPConfig) GetOTLPMetricsTimeout() time.Duration {
+	if oc.OtlpMetricsTimeout != nil {
+		return time.Duration(*oc.OtlpMetricsTimeout) * time.Millisecond
+	}
+
+	return oc.GetOTLPTimeout()
+}
+
+// GetOTLPLogsTimeout returns the export timeout of the OTLP logs exporter. Default is the otlpTimeout value.
+func (oc OTLPConfig) GetOTLPLogsTimeout() time.Duration {
+	if oc.OtlpLogsTimeout != nil {
+		return time.Duration(*oc.OtlpLogsTimeout) * time.Millisecond
+	}
+
+	return oc.GetOTLPTimeout()
+}
+
+// GetTracesExporter returns the type of traces exporter. Default is otlp.
+func (oc OTLPConfig) GetTracesExporter() OTELTracesExporterType {
+	if oc.TracesExporter == "" {
+		return OTELTracesExporterOTLP
+	}
+
+	return oc.TracesExporter
+}
+
 // GetMetricsExporter returns the type of metrics exporter. Default is none.
 func (oc OTLPConfig) GetMetricsExporter() OTELMetricsExporterType {
 	if oc.MetricsExporter == "" {
@@ -188,3 +726,70 @@ func (oc OTLPConfig) GetLogsExporter() OTELLogsExporterType {
 
 	return oc.LogsExporter
 }
+
+// GetTracesFilePath returns the file path of the file traces exporter. Default is traces.jsonl.
+func (oc OTLPConfig) GetTracesFilePath() string {
+	return getDefault(oc.TracesFilePath, defaultTracesFilePath)
+}
+
+// GetMetricsFilePath returns the file path of the file metrics exporter. Default is metrics.jsonl.
+func (oc OTLPConfig) GetMetricsFilePath() string {
+	return getDefault(oc.MetricsFilePath, defaultMetricsFilePath)
+}
+
+// GetLogsFilePath returns the file path of the file logs exporter. Default is logs.jsonl.
+func (oc OTLPConfig) GetLogsFilePath() string {
+	return getDefault(oc.LogsFilePath, defaultLogsFilePath)
+}
+
+// GetTracesSampler returns the type of traces sampler. Default is parentbased_always_on.
+func (oc OTLPConfig) GetTracesSampler() OTELTracesSamplerType {
+	if oc.TracesSampler == "" {
+		return OTELTracesSamplerParentBasedAlwaysOn
+	}
+
+	return oc.TracesSampler
+}
+
+// GetTracesSamplerArg returns the sampling probability of ratio-based samplers. Default is 1.
+func (oc OTLPConfig) GetTracesSamplerArg() float64 {
+	if oc.TracesSamplerArg == nil {
+		return 1
+	}
+
+	return *oc.TracesSamplerArg
+}
+
+// GetPropagators returns the list of context propagators. Default is tracecontext, b3multi.
+func (oc OTLPConfig) GetPropagators() []OTELPropagatorType {
+	if len(oc.Propagators) == 0 {
+		return defaultPropagators
+	}
+
+	return oc.Propagators
+}
+
+// GetResourceDetectors returns the list of resource detectors. Default is all detectors.
+func (oc OTLPConfig) GetResourceDetectors() []OTELResourceDetectorType {
+	if len(oc.ResourceDetectors) == 0 {
+		return defaultResourceDetectors
+	}
+
+	return oc.ResourceDetectors
+}
+
+func mergeOTLPHeaders(headers map[string]string, signalHeaders map[string]string) map[string]string {
+	if len(signalHeaders) == 0 {
+		return headers
+	}
+
+	if len(headers) == 0 {
+		return signalHeaders
+	}
+
+	result := make(map[string]string, len(headers)+len(signalHeaders))
+	maps.Copy(result, headers)
+	maps.Copy(result, signalHeaders)
+
+	return result
+}
@@ -0,0 +1,260 @@
+package gotel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	otelConfigFileEnv         = "OTEL_CONFIG_FILE"
+	defaultEnvSeparator       = ","
+	defaultEnvKeyValSeparator = "="
+)
+
+var errUnsupportedConfigFieldType = errors.New("unsupported config field type")
+
+// LoadOTLPConfig loads the OpenTelemetry configuration from an optional YAML or JSON file,
+// overlays environment variables and applies default values.
+// The file is decoded as JSON if the extension is .json, otherwise as YAML.
+// Environment variables take precedence over values of the file.
+// Returns a joined error of every invalid field if the configuration is invalid.
+//
+// If the file has the file_format field, or the file path is empty and the OTEL_CONFIG_FILE
+// environment variable is set, the file is loaded as a declarative configuration. See [LoadDeclarativeConfig].
+func LoadOTLPConfig(filePath string) (*OTLPConfig, error) {
+	if filePath == "" {
+		declarativeFilePath := os.Getenv(otelConfigFileEnv)
+		if declarativeFilePath != "" {
+			return LoadDeclarativeConfig(declarativeFilePath)
+		}
+	}
+
+	config := &OTLPConfig{}
+
+	if filePath != "" {
+		rawBytes, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the OTLP config file: %w", err)
+		}
+
+		if isDeclarativeConfig(rawBytes) {
+			return parseDeclarativeConfig(filePath, rawBytes)
+		}
+
+		err = decodeOTLPConfigFile(filePath, rawBytes, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// invalid environment variables are left unset, so the remaining fields are still validated.
+	envErr := applyOTLPConfigEnv(config)
+	defaultsErr := applyOTLPConfigDefaults(config)
+
+	err := errors.Join(envErr, defaultsErr, config.Validate())
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func decodeOTLPConfigFile(filePath string, rawBytes []byte, config *OTLPConfig) error {
+	var err error
+
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		err = json.Unmarshal(rawBytes, config)
+	} else {
+		err = yaml.Unmarshal(rawBytes, config)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to decode the OTLP config file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// overlay environment variables declared in the env tags of config fields.
+// Empty environment variables are treated as unset.
+func applyOTLPConfigEnv(config *OTLPConfig) error {
+	configValue := reflect.ValueOf(config).Elem()
+	configType := configValue.Type()
+	errs := []error{}
+
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+
+		envName := field.Tag.Get("env")
+		if envName == "" {
+			continue
+		}
+
+		envValue := strings.TrimSpace(os.Getenv(envName))
+		if envValue == "" {
+			continue
+		}
+
+		err := setConfigFieldFromString(configValue.Field(i), field.Tag, envValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse the environment variable %s: %w", envName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// apply the default tags to empty fields.
+func applyOTLPConfigDefaults(config *OTLPConfig) error {
+	configValue := reflect.ValueOf(config).Elem()
+	configType := configValue.Type()
+	errs := []error{}
+
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+
+		defaultValue := field.Tag.Get("default")
+		if defaultValue == "" || !configValue.Field(i).IsZero() {
+			continue
+		}
+
+		err := setConfigFieldFromString(configValue.Field(i), field.Tag, defaultValue)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply the default value of %s: %w", field.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func setConfigFieldFromString(fieldValue reflect.Value, tag reflect.StructTag, value string) error {
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Pointer:
+		elemValue := reflect.New(fieldValue.Type().Elem())
+
+		err := setConfigScalarFromString(elemValue.Elem(), value)
+		if err != nil {
+			return err
+		}
+
+		fieldValue.Set(elemValue)
+	case reflect.Slice:
+		items := splitConfigValues(value, getDefault(tag.Get("envSeparator"), defaultEnvSeparator))
+		sliceValue := reflect.MakeSlice(fieldValue.Type(), len(items), len(items))
+
+		for i, item := range items {
+			err := setConfigScalarFromString(sliceValue.Index(i), item)
+			if err != nil {
+				return err
+			}
+		}
+
+		fieldValue.Set(sliceValue)
+	case reflect.Map:
+		items := splitConfigValues(value, getDefault(tag.Get("mapsep"), defaultEnvSeparator))
+		keyValSeparator := getDefault(tag.Get("envKeyValSeparator"), defaultEnvKeyValSeparator)
+		mapValue := reflect.MakeMapWithSize(fieldValue.Type(), len(items))
+
+		for _, item := range items {
+			key, val, err := parseConfigKeyValue(item, keyValSeparator)
+			if err != nil {
+				return err
+			}
+
+			mapValue.SetMapIndex(
+				reflect.ValueOf(key).Convert(fieldValue.Type().Key()),
+				reflect.ValueOf(val).Convert(fieldValue.Type().Elem()),
+			)
+		}
+
+		fieldValue.Set(mapValue)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedConfigFieldType, fieldValue.Type())
+	}
+
+	return nil
+}
+
+func setConfigScalarFromString(fieldValue reflect.Value, value string) error {
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Bool:
+		result, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		fieldValue.SetBool(result)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result, err := strconv.ParseInt(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fieldValue.SetInt(result)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result, err := strconv.ParseUint(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fieldValue.SetUint(result)
+	case reflect.Float32, reflect.Float64:
+		result, err := strconv.ParseFloat(value, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fieldValue.SetFloat(result)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedConfigFieldType, fieldValue.Type())
+	}
+
+	return nil
+}
+
+// parse a key-value pair of lists in the W3C Baggage format, e.g. OTEL_EXPORTER_OTLP_HEADERS.
+// The key and value are trimmed and percent-decoded.
+func parseConfigKeyValue(item string, separator string) (string, string, error) {
+	rawKey, rawValue, ok := strings.Cut(item, separator)
+	if !ok {
+		return "", "", fmt.Errorf("invalid key-value pair %q, expected the %s separator", item, separator)
+	}
+
+	key, err := url.PathUnescape(strings.TrimSpace(rawKey))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid key-value pair %q: %w", item, err)
+	}
+
+	value, err := url.PathUnescape(strings.TrimSpace(rawValue))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid key-value pair %q: %w", item, err)
+	}
+
+	return key, value, nil
+}
+
+func splitConfigValues(value string, separator string) []string {
+	results := []string{}
+
+	for item := range strings.SplitSeq(value, separator) {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			results = append(results, item)
+		}
+	}
+
+	return results
+}
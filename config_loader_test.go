@@ -0,0 +1,354 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Helper function to write a config file into a temporary directory
+func writeTestConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	return filePath
+}
+
+func TestLoadOTLPConfig(t *testing.T) {
+	t.Run("applies defaults without file", func(t *testing.T) {
+		config, err := LoadOTLPConfig("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.OtlpCompression != OTLPCompressionGzip {
+			t.Errorf("expected compression gzip, got %s", config.OtlpCompression)
+		}
+
+		if config.TracesExporter != OTELTracesExporterOTLP {
+			t.Errorf("expected traces exporter otlp, got %s", config.TracesExporter)
+		}
+
+		if config.MetricsExporter != OTELMetricsExporterNone {
+			t.Errorf("expected metrics exporter none, got %s", config.MetricsExporter)
+		}
+
+		if config.TracesSampler != OTELTracesSamplerParentBasedAlwaysOn {
+			t.Errorf("expected traces sampler parentbased_always_on, got %s", config.TracesSampler)
+		}
+	})
+
+	t.Run("loads YAML file", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.yaml", `
+serviceName: yaml-service
+otlpEndpoint: http://localhost:4317
+otlpHeaders:
+  api-key: secret
+metricsExporter: prometheus
+prometheusPort: 9090
+tracesSamplerArg: 0.5
+propagators:
+  - tracecontext
+  - baggage
+`)
+
+		config, err := LoadOTLPConfig(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.ServiceName != "yaml-service" {
+			t.Errorf("expected service name yaml-service, got %s", config.ServiceName)
+		}
+
+		if config.OtlpHeaders["api-key"] != "secret" {
+			t.Errorf("expected api-key header, got %v", config.OtlpHeaders)
+		}
+
+		if config.MetricsExporter != OTELMetricsExporterPrometheus {
+			t.Errorf("expected metrics exporter prometheus, got %s", config.MetricsExporter)
+		}
+
+		if config.PrometheusPort == nil || *config.PrometheusPort != 9090 {
+			t.Errorf("expected prometheus port 9090, got %v", config.PrometheusPort)
+		}
+
+		if config.GetTracesSamplerArg() != 0.5 {
+			t.Errorf("expected traces sampler arg 0.5, got %f", config.GetTracesSamplerArg())
+		}
+
+		expectedPropagators := []OTELPropagatorType{OTELPropagatorTraceContext, OTELPropagatorBaggage}
+		if !slices.Equal(config.Propagators, expectedPropagators) {
+			t.Errorf("expected propagators %v, got %v", expectedPropagators, config.Propagators)
+		}
+	})
+
+	t.Run("loads JSON file", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.json", `{
+			"serviceName": "json-service",
+			"otlpCompression": "none",
+			"logsExporter": "console"
+		}`)
+
+		config, err := LoadOTLPConfig(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.ServiceName != "json-service" {
+			t.Errorf("expected service name json-service, got %s", config.ServiceName)
+		}
+
+		if config.OtlpCompression != OTLPCompressionNone {
+			t.Errorf("expected compression none, got %s", config.OtlpCompression)
+		}
+
+		if config.LogsExporter != OTELLogsExporterConsole {
+			t.Errorf("expected logs exporter console, got %s", config.LogsExporter)
+		}
+	})
+
+	t.Run("environment variables override the file", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.yaml", `
+serviceName: yaml-service
+tracesExporter: console
+`)
+
+		t.Setenv("OTEL_SERVICE_NAME", "env-service")
+		t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "api-key=secret, x-tenant = acme, Authorization=Basic%20abc%3D")
+		t.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
+		t.Setenv("OTEL_BSP_MAX_QUEUE_SIZE", "1024")
+		t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+		t.Setenv("OTEL_PROPAGATORS", "b3,jaeger")
+		t.Setenv("OTEL_TRACES_EXPORTER", "")
+
+		config, err := LoadOTLPConfig(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.ServiceName != "env-service" {
+			t.Errorf("expected service name env-service, got %s", config.ServiceName)
+		}
+
+		if config.TracesExporter != OTELTracesExporterConsole {
+			t.Errorf("expected empty env to keep traces exporter console, got %s", config.TracesExporter)
+		}
+
+		if config.OtlpHeaders["api-key"] != "secret" || config.OtlpHeaders["x-tenant"] != "acme" {
+			t.Errorf("unexpected headers: %v", config.OtlpHeaders)
+		}
+
+		if config.OtlpHeaders["Authorization"] != "Basic abc=" {
+			t.Errorf("expected percent-decoded Authorization header, got %q", config.OtlpHeaders["Authorization"])
+		}
+
+		if config.OtlpInsecure == nil || !*config.OtlpInsecure {
+			t.Errorf("expected insecure to be true, got %v", config.OtlpInsecure)
+		}
+
+		if config.TracesMaxQueueSize == nil || *config.TracesMaxQueueSize != 1024 {
+			t.Errorf("expected traces max queue size 1024, got %v", config.TracesMaxQueueSize)
+		}
+
+		if config.GetTracesSamplerArg() != 0.25 {
+			t.Errorf("expected traces sampler arg 0.25, got %f", config.GetTracesSamplerArg())
+		}
+
+		expectedPropagators := []OTELPropagatorType{OTELPropagatorB3, OTELPropagatorJaeger}
+		if !slices.Equal(config.Propagators, expectedPropagators) {
+			t.Errorf("expected propagators %v, got %v", expectedPropagators, config.Propagators)
+		}
+	})
+
+	t.Run("applies the cardinality limit from the environment", func(t *testing.T) {
+		t.Setenv("OTEL_SERVICE_NAME", "cardinality-service")
+		t.Setenv("OTEL_TRACES_EXPORTER", "none")
+		t.Setenv("OTEL_METRICS_CARDINALITY_LIMIT", "2")
+
+		config, err := LoadOTLPConfig("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.MetricsCardinalityLimit == nil || *config.MetricsCardinalityLimit != 2 {
+			t.Fatalf("expected metrics cardinality limit 2, got %v", config.MetricsCardinalityLimit)
+		}
+
+		reader := metric.NewManualReader()
+
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			config,
+			"v1.0.0",
+			slog.New(slog.NewJSONHandler(io.Discard, nil)),
+			WithMetricReader(reader),
+			WithGlobalRegistration(false),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		counter, err := exporters.Meter.Int64Counter("cardinality.requests")
+		if err != nil {
+			t.Fatalf("failed to create counter: %v", err)
+		}
+
+		for _, route := range []string{"/a", "/b", "/c"} {
+			counter.Add(context.Background(), 1, metricapi.WithAttributes(attribute.String("http.route", route)))
+		}
+
+		var metrics metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &metrics); err != nil {
+			t.Fatalf("failed to collect metrics: %v", err)
+		}
+
+		for _, scopeMetrics := range metrics.ScopeMetrics {
+			for _, m := range scopeMetrics.Metrics {
+				if m.Name != "cardinality.requests" {
+					continue
+				}
+
+				// the limit includes the overflow series.
+				data, _ := m.Data.(metricdata.Sum[int64])
+				if len(data.DataPoints) != 2 ||
+					!slices.ContainsFunc(data.DataPoints, func(dp metricdata.DataPoint[int64]) bool {
+						return dp.Attributes.HasValue("otel.metric.overflow")
+					}) {
+					t.Errorf("expected 2 data points with the overflow series, got %+v", m.Data)
+				}
+
+				return
+			}
+		}
+
+		t.Error("expected the cardinality.requests metric")
+	})
+
+	t.Run("enables host metrics from the environment", func(t *testing.T) {
+		t.Setenv("OTEL_HOST_METRICS_ENABLED", "true")
+		t.Setenv("OTEL_HOST_METRICS_PROCFS_PATH", "/host/proc")
+
+		config, err := LoadOTLPConfig("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.EnableHostMetrics == nil || !*config.EnableHostMetrics {
+			t.Errorf("expected host metrics to be enabled, got %v", config.EnableHostMetrics)
+		}
+
+		if config.HostMetricsProcfsPath != "/host/proc" {
+			t.Errorf("expected procfs path /host/proc, got %s", config.HostMetricsProcfsPath)
+		}
+	})
+
+	t.Run("returns error for invalid environment variables", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_PROMETHEUS_PORT", "abc")
+		t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "invalid")
+
+		_, err := LoadOTLPConfig("")
+		if err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("aggregates environment variable and validation errors", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_PROMETHEUS_PORT", "abc")
+		t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/json")
+
+		_, err := LoadOTLPConfig("")
+		if err == nil {
+			t.Fatal("expected error but got none")
+		}
+
+		expectedErrors := []error{
+			strconv.ErrSyntax,
+			ErrInvalidOTELTracesExporterType,
+			ErrInvalidOTLPProtocol,
+		}
+
+		for _, expected := range expectedErrors {
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error to contain %v, got: %v", expected, err)
+			}
+		}
+
+		if !strings.Contains(err.Error(), "OTEL_EXPORTER_PROMETHEUS_PORT") {
+			t.Errorf("expected error to name the invalid environment variable, got: %v", err)
+		}
+	})
+
+	t.Run("returns error for missing file", func(t *testing.T) {
+		_, err := LoadOTLPConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+		if err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("returns error for malformed file", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.json", `{"serviceName": `)
+
+		_, err := LoadOTLPConfig(filePath)
+		if err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("aggregates validation errors", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.yaml", `
+otlpProtocol: http/json
+otlpTracesCompression: zstd
+otlpLogsEndpoint: "http://"
+tracesExporter: zipkin
+metricsExporter: otlp
+tracesSamplerArg: 2
+prometheusPort: 70000
+propagators:
+  - xray
+otlpClientCertificate: client.pem
+`)
+
+		_, err := LoadOTLPConfig(filePath)
+		if err == nil {
+			t.Fatal("expected error but got none")
+		}
+
+		expectedErrors := []error{
+			ErrInvalidOTLPProtocol,
+			ErrInvalidOTLPCompressionType,
+			ErrInvalidOTLPEndpoint,
+			ErrInvalidOTELTracesExporterType,
+			ErrMetricsOTLPEndpointRequired,
+			ErrInvalidOTELTracesSamplerArg,
+			ErrInvalidPrometheusPort,
+			ErrInvalidOTELPropagatorType,
+			ErrOTLPClientKeyPairRequired,
+		}
+
+		for _, expected := range expectedErrors {
+			if !errors.Is(err, expected) {
+				t.Errorf("expected error to contain %v, got: %v", expected, err)
+			}
+		}
+	})
+}
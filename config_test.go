@@ -2,7 +2,9 @@ package gotel
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestOTLPConfig_UnmarshalJSON(t *testing.T) {
@@ -700,3 +702,463 @@ func TestOTLPConfig_MarshalJSON(t *testing.T) {
 		}
 	})
 }
+
+func TestOTLPConfig_GetTracesSampler(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected OTELTracesSamplerType
+	}{
+		{
+			name:     "returns default parentbased_always_on when empty",
+			config:   OTLPConfig{},
+			expected: OTELTracesSamplerParentBasedAlwaysOn,
+		},
+		{
+			name: "returns configured sampler",
+			config: OTLPConfig{
+				TracesSampler: OTELTracesSamplerTraceIDRatio,
+			},
+			expected: OTELTracesSamplerTraceIDRatio,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetTracesSampler()
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetTracesSamplerArg(t *testing.T) {
+	ratio := 0.25
+
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected float64
+	}{
+		{
+			name:     "returns default 1 when empty",
+			config:   OTLPConfig{},
+			expected: 1,
+		},
+		{
+			name: "returns configured ratio",
+			config: OTLPConfig{
+				TracesSamplerArg: &ratio,
+			},
+			expected: 0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetTracesSamplerArg()
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetOTLPHeaders(t *testing.T) {
+	config := OTLPConfig{
+		OtlpHeaders: map[string]string{
+			"x-api-key": "global",
+			"x-tenant":  "acme",
+		},
+		OtlpTracesHeaders: map[string]string{
+			"x-api-key": "traces",
+		},
+		OtlpLogsHeaders: map[string]string{
+			"x-logs": "true",
+		},
+	}
+
+	t.Run("prefers signal headers over general", func(t *testing.T) {
+		headers := config.GetOTLPTracesHeaders()
+		if headers["x-api-key"] != "traces" {
+			t.Errorf("expected x-api-key 'traces', got '%s'", headers["x-api-key"])
+		}
+		if headers["x-tenant"] != "acme" {
+			t.Errorf("expected x-tenant 'acme', got '%s'", headers["x-tenant"])
+		}
+	})
+
+	t.Run("falls back to general headers", func(t *testing.T) {
+		headers := config.GetOTLPMetricsHeaders()
+		if len(headers) != 2 || headers["x-api-key"] != "global" {
+			t.Errorf("expected general headers, got %v", headers)
+		}
+	})
+
+	t.Run("merges signal headers", func(t *testing.T) {
+		headers := config.GetOTLPLogsHeaders()
+		if len(headers) != 3 || headers["x-logs"] != "true" || headers["x-api-key"] != "global" {
+			t.Errorf("expected merged headers, got %v", headers)
+		}
+	})
+
+	t.Run("does not modify general headers", func(t *testing.T) {
+		if len(config.OtlpHeaders) != 2 || config.OtlpHeaders["x-api-key"] != "global" {
+			t.Errorf("expected general headers to be unchanged, got %v", config.OtlpHeaders)
+		}
+	})
+}
+
+func TestOTLPConfig_GetOTLPTimeout(t *testing.T) {
+	timeout := uint(5000)
+	tracesTimeout := uint(1500)
+
+	tests := []struct {
+		name     string
+		result   func(OTLPConfig) time.Duration
+		config   OTLPConfig
+		expected time.Duration
+	}{
+		{
+			name:     "returns default 10s when empty",
+			result:   OTLPConfig.GetOTLPTimeout,
+			config:   OTLPConfig{},
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "returns configured timeout",
+			result:   OTLPConfig.GetOTLPTimeout,
+			config:   OTLPConfig{OtlpTimeout: &timeout},
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "prefers traces timeout over general",
+			result:   OTLPConfig.GetOTLPTracesTimeout,
+			config:   OTLPConfig{OtlpTimeout: &timeout, OtlpTracesTimeout: &tracesTimeout},
+			expected: 1500 * time.Millisecond,
+		},
+		{
+			name:     "metrics timeout falls back to general",
+			result:   OTLPConfig.GetOTLPMetricsTimeout,
+			config:   OTLPConfig{OtlpTimeout: &timeout},
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "logs timeout falls back to default",
+			result:   OTLPConfig.GetOTLPLogsTimeout,
+			config:   OTLPConfig{},
+			expected: 10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.result(tt.config)
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetFilePaths(t *testing.T) {
+	t.Run("returns default file paths when empty", func(t *testing.T) {
+		config := OTLPConfig{}
+
+		if config.GetTracesFilePath() != "traces.jsonl" {
+			t.Errorf("expected 'traces.jsonl', got '%s'", config.GetTracesFilePath())
+		}
+		if config.GetMetricsFilePath() != "metrics.jsonl" {
+			t.Errorf("expected 'metrics.jsonl', got '%s'", config.GetMetricsFilePath())
+		}
+		if config.GetLogsFilePath() != "logs.jsonl" {
+			t.Errorf("expected 'logs.jsonl', got '%s'", config.GetLogsFilePath())
+		}
+	})
+
+	t.Run("returns configured file paths", func(t *testing.T) {
+		config := OTLPConfig{
+			TracesFilePath:  "/tmp/traces.json",
+			MetricsFilePath: "/tmp/metrics.json",
+			LogsFilePath:    "/tmp/logs.json",
+		}
+
+		if config.GetTracesFilePath() != "/tmp/traces.json" {
+			t.Errorf("expected '/tmp/traces.json', got '%s'", config.GetTracesFilePath())
+		}
+		if config.GetMetricsFilePath() != "/tmp/metrics.json" {
+			t.Errorf("expected '/tmp/metrics.json', got '%s'", config.GetMetricsFilePath())
+		}
+		if config.GetLogsFilePath() != "/tmp/logs.json" {
+			t.Errorf("expected '/tmp/logs.json', got '%s'", config.GetLogsFilePath())
+		}
+	})
+}
+
+func TestOTLPConfig_GetTracesExporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected OTELTracesExporterType
+	}{
+		{
+			name:     "returns default otlp when empty",
+			config:   OTLPConfig{},
+			expected: OTELTracesExporterOTLP,
+		},
+		{
+			name: "returns none exporter",
+			config: OTLPConfig{
+				TracesExporter: OTELTracesExporterNone,
+			},
+			expected: OTELTracesExporterNone,
+		},
+		{
+			name: "returns console exporter",
+			config: OTLPConfig{
+				TracesExporter: OTELTracesExporterConsole,
+			},
+			expected: OTELTracesExporterConsole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetTracesExporter()
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetPropagators(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected []OTELPropagatorType
+	}{
+		{
+			name:     "returns default propagators when empty",
+			config:   OTLPConfig{},
+			expected: []OTELPropagatorType{OTELPropagatorTraceContext, OTELPropagatorB3Multi},
+		},
+		{
+			name: "returns configured propagators",
+			config: OTLPConfig{
+				Propagators: []OTELPropagatorType{OTELPropagatorBaggage, OTELPropagatorJaeger},
+			},
+			expected: []OTELPropagatorType{OTELPropagatorBaggage, OTELPropagatorJaeger},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetPropagators()
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetResourceDetectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected []OTELResourceDetectorType
+	}{
+		{
+			name:     "returns all detectors when empty",
+			config:   OTLPConfig{},
+			expected: defaultResourceDetectors,
+		},
+		{
+			name: "returns configured detectors",
+			config: OTLPConfig{
+				ResourceDetectors: []OTELResourceDetectorType{OTELResourceDetectorEnv, OTELResourceDetectorK8s},
+			},
+			expected: []OTELResourceDetectorType{OTELResourceDetectorEnv, OTELResourceDetectorK8s},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetResourceDetectors()
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetOTLPMetricsTemporalityPreference(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected OTLPMetricsTemporalityPreference
+	}{
+		{
+			name:     "returns cumulative when empty",
+			config:   OTLPConfig{},
+			expected: OTLPMetricsTemporalityCumulative,
+		},
+		{
+			name:     "returns configured preference",
+			config:   OTLPConfig{OtlpMetricsTemporalityPreference: OTLPMetricsTemporalityDelta},
+			expected: OTLPMetricsTemporalityDelta,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetOTLPMetricsTemporalityPreference()
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetOTLPMetricsDefaultHistogramAggregation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected OTELMetricAggregationType
+	}{
+		{
+			name:     "returns explicit bucket histogram when empty",
+			config:   OTLPConfig{},
+			expected: OTELMetricAggregationExplicitBucketHistogram,
+		},
+		{
+			name: "returns configured aggregation",
+			config: OTLPConfig{
+				OtlpMetricsDefaultHistogramAggregation: OTELMetricAggregationBase2ExponentialBucketHistogram,
+			},
+			expected: OTELMetricAggregationBase2ExponentialBucketHistogram,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetOTLPMetricsDefaultHistogramAggregation()
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetMetricsExemplarFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected OTELMetricsExemplarFilterType
+	}{
+		{
+			name:     "returns trace_based when empty",
+			config:   OTLPConfig{},
+			expected: OTELMetricsExemplarFilterTraceBased,
+		},
+		{
+			name:     "returns configured filter",
+			config:   OTLPConfig{MetricsExemplarFilter: OTELMetricsExemplarFilterAlwaysOff},
+			expected: OTELMetricsExemplarFilterAlwaysOff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetMetricsExemplarFilter()
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetPrometheusTranslationStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected PrometheusTranslationStrategy
+	}{
+		{
+			name:     "returns underscore escaping with suffixes when empty",
+			config:   OTLPConfig{},
+			expected: PrometheusTranslationUnderscoreEscapingWithSuffixes,
+		},
+		{
+			name:     "returns configured strategy",
+			config:   OTLPConfig{PrometheusTranslationStrategy: PrometheusTranslationNoTranslation},
+			expected: PrometheusTranslationNoTranslation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetPrometheusTranslationStrategy()
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetPushgatewayJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected string
+	}{
+		{
+			name:     "returns service name when empty",
+			config:   OTLPConfig{ServiceName: "batch"},
+			expected: "batch",
+		},
+		{
+			name:     "returns configured job",
+			config:   OTLPConfig{ServiceName: "batch", PushgatewayJob: "nightly"},
+			expected: "nightly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetPushgatewayJob()
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_GetStatsdFlavor(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   OTLPConfig
+		expected StatsdFlavor
+	}{
+		{
+			name:     "returns dogstatsd when empty",
+			config:   OTLPConfig{},
+			expected: StatsdFlavorDogStatsd,
+		},
+		{
+			name:     "returns configured flavor",
+			config:   OTLPConfig{StatsdFlavor: StatsdFlavorStatsd},
+			expected: StatsdFlavorStatsd,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetStatsdFlavor()
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package gotel
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const maxPort = 65535
+
+// ConfigFieldError represents a validation error of a configuration field.
+type ConfigFieldError struct {
+	// Path of the invalid field in the configuration, e.g. tracesExporter or propagators[0].
+	Path string
+	// Err is the underlying error that wraps one of the exported Err sentinel errors.
+	Err error
+}
+
+// Error implements the error interface.
+func (cfe *ConfigFieldError) Error() string {
+	return cfe.Path + ": " + cfe.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (cfe *ConfigFieldError) Unwrap() error {
+	return cfe.Err
+}
+
+func newConfigFieldError(path string, err error) *ConfigFieldError {
+	return &ConfigFieldError{
+		Path: path,
+		Err:  err,
+	}
+}
+
+var (
+	otlpProtocols = []OTLPProtocol{
+		OTLPProtocolGRPC,
+		OTLPProtocolHTTPProtobuf,
+	}
+	otlpCompressionTypes = []OTLPCompressionType{
+		OTLPCompressionNone,
+		OTLPCompressionGzip,
+	}
+	otelTracesExporterTypes = []OTELTracesExporterType{
+		OTELTracesExporterNone,
+		OTELTracesExporterOTLP,
+		OTELTracesExporterConsole,
+		OTELTracesExporterFile,
+	}
+	otelMetricsExporterTypes = []OTELMetricsExporterType{
+		OTELMetricsExporterNone,
+		OTELMetricsExporterOTLP,
+		OTELMetricsExporterPrometheus,
+		OTELMetricsExporterConsole,
+		OTELMetricsExporterFile,
+		OTELMetricsExporterPushgateway,
+		OTELMetricsExporterPrometheusRemoteWrite,
+		OTELMetricsExporterStatsd,
+	}
+	otelLogsExporterTypes = []OTELLogsExporterType{
+		OTELLogsExporterNone,
+		OTELLogsExporterOTLP,
+		OTELLogsExporterConsole,
+		OTELLogsExporterFile,
+	}
+	otelTracesSamplerTypes = []OTELTracesSamplerType{
+		OTELTracesSamplerAlwaysOn,
+		OTELTracesSamplerAlwaysOff,
+		OTELTracesSamplerTraceIDRatio,
+		OTELTracesSamplerParentBasedAlwaysOn,
+		OTELTracesSamplerParentBasedAlwaysOff,
+		OTELTracesSamplerParentBasedTraceIDRatio,
+	}
+	otelPropagatorTypes = []OTELPropagatorType{
+		OTELPropagatorNone,
+		OTELPropagatorTraceContext,
+		OTELPropagatorBaggage,
+		OTELPropagatorB3,
+		OTELPropagatorB3Multi,
+		OTELPropagatorJaeger,
+	}
+	otelMetricsExemplarFilterTypes = []OTELMetricsExemplarFilterType{
+		OTELMetricsExemplarFilterTraceBased,
+		OTELMetricsExemplarFilterAlwaysOn,
+		OTELMetricsExemplarFilterAlwaysOff,
+	}
+	prometheusTranslationStrategies = []PrometheusTranslationStrategy{
+		PrometheusTranslationUnderscoreEscapingWithSuffixes,
+		PrometheusTranslationUnderscoreEscapingWithoutSuffixes,
+		PrometheusTranslationNoUTF8EscapingWithSuffixes,
+		PrometheusTranslationNoTranslation,
+	}
+	statsdFlavors = []StatsdFlavor{
+		StatsdFlavorDogStatsd,
+		StatsdFlavorStatsd,
+	}
+	otlpMetricsTemporalityPreferences = []OTLPMetricsTemporalityPreference{
+		OTLPMetricsTemporalityCumulative,
+		OTLPMetricsTemporalityDelta,
+		OTLPMetricsTemporalityLowMemory,
+	}
+	otlpMetricsHistogramAggregationTypes = []OTELMetricAggregationType{
+		OTELMetricAggregationExplicitBucketHistogram,
+		OTELMetricAggregationBase2ExponentialBucketHistogram,
+	}
+	otelMetricAggregationTypes = []OTELMetricAggregationType{
+		OTELMetricAggregationDefault,
+		OTELMetricAggregationDrop,
+		OTELMetricAggregationSum,
+		OTELMetricAggregationLastValue,
+		OTELMetricAggregationExplicitBucketHistogram,
+		OTELMetricAggregationBase2ExponentialBucketHistogram,
+	}
+	otelResourceDetectorTypes = []OTELResourceDetectorType{
+		OTELResourceDetectorNone,
+		OTELResourceDetectorEnv,
+		OTELResourceDetectorHost,
+		OTELResourceDetectorProcess,
+		OTELResourceDetectorContainer,
+		OTELResourceDetectorK8s,
+		OTELResourceDetectorService,
+		OTELResourceDetectorDeployment,
+	}
+)
+
+// Validate checks every field of the configuration and returns a joined error of all invalid fields.
+// Each error is a [ConfigFieldError] that wraps one of the exported Err sentinel errors,
+// so it can be inspected with [errors.Is] and [errors.As].
+// Empty enum values are valid because getters fall back to default values.
+func (oc OTLPConfig) Validate() error {
+	errs := []error{
+		validateEnum("otlpProtocol", oc.OtlpProtocol, otlpProtocols, ErrInvalidOTLPProtocol),
+		validateEnum("otlpTracesProtocol", oc.OtlpTracesProtocol, otlpProtocols, ErrInvalidOTLPProtocol),
+		validateEnum("otlpMetricsProtocol", oc.OtlpMetricsProtocol, otlpProtocols, ErrInvalidOTLPProtocol),
+		validateEnum("otlpLogsProtocol", oc.OtlpLogsProtocol, otlpProtocols, ErrInvalidOTLPProtocol),
+		validateEnum("otlpCompression", oc.OtlpCompression, otlpCompressionTypes, ErrInvalidOTLPCompressionType),
+		validateEnum(
+			"otlpTracesCompression", oc.OtlpTracesCompression, otlpCompressionTypes, ErrInvalidOTLPCompressionType,
+		),
+		validateEnum(
+			"otlpMetricsCompression", oc.OtlpMetricsCompression, otlpCompressionTypes, ErrInvalidOTLPCompressionType,
+		),
+		validateEnum("otlpLogsCompression", oc.OtlpLogsCompression, otlpCompressionTypes, ErrInvalidOTLPCompressionType),
+		validateEnum(
+			"otlpMetricsTemporalityPreference",
+			oc.OtlpMetricsTemporalityPreference,
+			otlpMetricsTemporalityPreferences,
+			ErrInvalidOTLPMetricsTemporalityPreference,
+		),
+		validateEnum(
+			"otlpMetricsDefaultHistogramAggregation",
+			oc.OtlpMetricsDefaultHistogramAggregation,
+			otlpMetricsHistogramAggregationTypes,
+			ErrInvalidOTELMetricAggregationType,
+		),
+		validateOTLPEndpoint("otlpEndpoint", oc.OtlpEndpoint),
+		validateOTLPEndpoint("otlpTracesEndpoint", oc.OtlpTracesEndpoint),
+		validateOTLPEndpoint("otlpMetricsEndpoint", oc.OtlpMetricsEndpoint),
+		validateOTLPEndpoint("otlpLogsEndpoint", oc.OtlpLogsEndpoint),
+		validateOTLPClientKeyPair("", oc.OtlpClientCertificate, oc.OtlpClientKey),
+		validatePushgatewayURL("pushgatewayUrl", oc.PushgatewayURL),
+		validateStatsdAddress("statsdAddress", oc.StatsdAddress),
+		validateEnum("tracesExporter", oc.TracesExporter, otelTracesExporterTypes, ErrInvalidOTELTracesExporterType),
+		validateEnum("metricsExporter", oc.MetricsExporter, otelMetricsExporterTypes, ErrInvalidOTELMetricExporterType),
+		validateEnum("logsExporter", oc.LogsExporter, otelLogsExporterTypes, ErrInvalidOTELLogsExporterType),
+		validateEnum(
+			"metricsExemplarFilter",
+			oc.MetricsExemplarFilter,
+			otelMetricsExemplarFilterTypes,
+			ErrInvalidOTELMetricsExemplarFilterType,
+		),
+		validateEnum("tracesSampler", oc.TracesSampler, otelTracesSamplerTypes, ErrInvalidOTELTracesSamplerType),
+		validateEnum(
+			"prometheusTranslationStrategy",
+			oc.PrometheusTranslationStrategy,
+			prometheusTranslationStrategies,
+			ErrInvalidPrometheusTranslationStrategy,
+		),
+		validateEnum("statsdFlavor", oc.StatsdFlavor, statsdFlavors, ErrInvalidStatsdFlavor),
+	}
+
+	// signal-specific certificates and keys fall back to the global ones.
+	if oc.OtlpTracesClientCertificate != "" || oc.OtlpTracesClientKey != "" {
+		errs = append(errs, validateOTLPClientKeyPair(
+			"Traces",
+			getDefault(oc.OtlpTracesClientCertificate, oc.OtlpClientCertificate),
+			getDefault(oc.OtlpTracesClientKey, oc.OtlpClientKey),
+		))
+	}
+
+	if oc.OtlpMetricsClientCertificate != "" || oc.OtlpMetricsClientKey != "" {
+		errs = append(errs, validateOTLPClientKeyPair(
+			"Metrics",
+			getDefault(oc.OtlpMetricsClientCertificate, oc.OtlpClientCertificate),
+			getDefault(oc.OtlpMetricsClientKey, oc.OtlpClientKey),
+		))
+	}
+
+	if oc.OtlpLogsClientCertificate != "" || oc.OtlpLogsClientKey != "" {
+		errs = append(errs, validateOTLPClientKeyPair(
+			"Logs",
+			getDefault(oc.OtlpLogsClientCertificate, oc.OtlpClientCertificate),
+			getDefault(oc.OtlpLogsClientKey, oc.OtlpClientKey),
+		))
+	}
+
+	for i, propagator := range oc.Propagators {
+		errs = append(
+			errs,
+			validateEnum(fmt.Sprintf("propagators[%d]", i), propagator, otelPropagatorTypes, ErrInvalidOTELPropagatorType),
+		)
+	}
+
+	for i, detector := range oc.ResourceDetectors {
+		errs = append(errs, validateEnum(
+			fmt.Sprintf("resourceDetectors[%d]", i), detector, otelResourceDetectorTypes, ErrInvalidOTELResourceDetectorType,
+		))
+	}
+
+	for i, view := range oc.MetricViews {
+		errs = append(errs, view.validate(fmt.Sprintf("metricViews[%d]", i)))
+	}
+
+	if oc.TracesSamplerArg != nil && (*oc.TracesSamplerArg < 0 || *oc.TracesSamplerArg > 1) {
+		errs = append(errs, newConfigFieldError(
+			"tracesSamplerArg",
+			fmt.Errorf("%w: %f", ErrInvalidOTELTracesSamplerArg, *oc.TracesSamplerArg),
+		))
+	}
+
+	if oc.PrometheusPort != nil && (*oc.PrometheusPort == 0 || *oc.PrometheusPort > maxPort) {
+		errs = append(errs, newConfigFieldError(
+			"prometheusPort",
+			fmt.Errorf("%w: %d", ErrInvalidPrometheusPort, *oc.PrometheusPort),
+		))
+	}
+
+	metricsExporterType := oc.GetMetricsExporter()
+
+	if metricsExporterType == OTELMetricsExporterPrometheusRemoteWrite {
+		errs = append(errs, validateRemoteWriteViews(oc.MetricViews))
+	}
+
+	requiresMetricsEndpoint := metricsExporterType == OTELMetricsExporterOTLP ||
+		metricsExporterType == OTELMetricsExporterPrometheusRemoteWrite
+
+	if requiresMetricsEndpoint && oc.OtlpMetricsEndpoint == "" && oc.OtlpEndpoint == "" {
+		errs = append(errs, newConfigFieldError("otlpMetricsEndpoint", ErrMetricsOTLPEndpointRequired))
+	}
+
+	if metricsExporterType == OTELMetricsExporterPushgateway {
+		if oc.PushgatewayURL == "" {
+			errs = append(errs, newConfigFieldError("pushgatewayUrl", ErrPushgatewayURLRequired))
+		}
+
+		if oc.GetPushgatewayJob() == "" {
+			errs = append(errs, newConfigFieldError("pushgatewayJob", ErrPushgatewayJobRequired))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate that the client certificate and key are set together.
+// The error path points to the missing field.
+func validateOTLPClientKeyPair(signal string, clientCertificate string, clientKey string) error {
+	switch {
+	case clientCertificate == "" && clientKey != "":
+		return newConfigFieldError("otlp"+signal+"ClientCertificate", ErrOTLPClientKeyPairRequired)
+	case clientCertificate != "" && clientKey == "":
+		return newConfigFieldError("otlp"+signal+"ClientKey", ErrOTLPClientKeyPairRequired)
+	default:
+		return nil
+	}
+}
+
+func validateEnum[T ~string](field string, value T, enums []T, sentinel error) error {
+	if value == "" || slices.Contains(enums, value) {
+		return nil
+	}
+
+	return newConfigFieldError(field, fmt.Errorf("%w: %s", sentinel, value))
+}
+
+func validateOTLPEndpoint(field string, endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "https://" + endpoint
+	}
+
+	uri, err := url.Parse(endpoint)
+	if err != nil {
+		return newConfigFieldError(field, fmt.Errorf("%w: %w", ErrInvalidOTLPEndpoint, err))
+	}
+
+	if uri.Hostname() == "" {
+		return newConfigFieldError(field, fmt.Errorf("%w: %s", ErrInvalidOTLPEndpoint, endpoint))
+	}
+
+	return nil
+}
+
+// validate that metric views do not aggregate to exponential histograms
+// that the Prometheus remote-write exporter cannot send.
+func validateRemoteWriteViews(views []MetricViewConfig) error {
+	errs := []error{}
+
+	for i, view := range views {
+		if view.Aggregation == OTELMetricAggregationBase2ExponentialBucketHistogram {
+			errs = append(errs, newConfigFieldError(
+				fmt.Sprintf("metricViews[%d].aggregation", i),
+				fmt.Errorf("%w: %s", ErrUnsupportedRemoteWriteAggregation, view.Aggregation),
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate the Pushgateway URL the same way the Pushgateway client parses it,
+// i.e. URLs without scheme default to http.
+func validatePushgatewayURL(field string, rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+
+	uri, err := url.Parse(rawURL)
+	if err != nil {
+		return newConfigFieldError(field, fmt.Errorf("%w: %w", ErrInvalidPushgatewayURL, err))
+	}
+
+	if (uri.Scheme != "http" && uri.Scheme != "https") || uri.Hostname() == "" {
+		return newConfigFieldError(field, fmt.Errorf("%w: %s", ErrInvalidPushgatewayURL, rawURL))
+	}
+
+	return nil
+}
+
+// validate that the StatsD address has a port. An empty host sends lines to the local host.
+func validateStatsdAddress(field string, address string) error {
+	if address == "" {
+		return nil
+	}
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return newConfigFieldError(field, fmt.Errorf("%w: %w", ErrInvalidStatsdAddress, err))
+	}
+
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || portNumber == 0 {
+		return newConfigFieldError(field, fmt.Errorf("%w: %s", ErrInvalidStatsdAddress, address))
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+package gotel
+
+import (
+	"errors"
+	"testing"
+)
+
+// Helper function to create float64 pointers
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestOTLPConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        OTLPConfig
+		ExpectedPath  string
+		ExpectedError error
+	}{
+		{
+			Name:   "empty config is valid",
+			Config: OTLPConfig{},
+		},
+		{
+			Name: "valid config",
+			Config: OTLPConfig{
+				OtlpEndpoint:          "localhost:4317",
+				OtlpProtocol:          OTLPProtocolGRPC,
+				OtlpCompression:       OTLPCompressionGzip,
+				MetricsExporter:       OTELMetricsExporterOTLP,
+				TracesSampler:         OTELTracesSamplerTraceIDRatio,
+				TracesSamplerArg:      float64Ptr(0.5),
+				Propagators:           []OTELPropagatorType{OTELPropagatorTraceContext},
+				OtlpClientCertificate: "client.pem",
+				OtlpClientKey:         "client-key.pem",
+				OtlpTracesCertificate: "ca.pem",
+			},
+		},
+		{
+			Name:          "invalid protocol",
+			Config:        OTLPConfig{OtlpTracesProtocol: "http/json"},
+			ExpectedPath:  "otlpTracesProtocol",
+			ExpectedError: ErrInvalidOTLPProtocol,
+		},
+		{
+			Name:          "invalid compression",
+			Config:        OTLPConfig{OtlpCompression: "zstd"},
+			ExpectedPath:  "otlpCompression",
+			ExpectedError: ErrInvalidOTLPCompressionType,
+		},
+		{
+			Name:          "invalid endpoint",
+			Config:        OTLPConfig{OtlpMetricsEndpoint: "http://"},
+			ExpectedPath:  "otlpMetricsEndpoint",
+			ExpectedError: ErrInvalidOTLPEndpoint,
+		},
+		{
+			Name:          "invalid traces exporter",
+			Config:        OTLPConfig{TracesExporter: "zipkin"},
+			ExpectedPath:  "tracesExporter",
+			ExpectedError: ErrInvalidOTELTracesExporterType,
+		},
+		{
+			Name:          "invalid metrics temporality preference",
+			Config:        OTLPConfig{OtlpMetricsTemporalityPreference: "stateless"},
+			ExpectedPath:  "otlpMetricsTemporalityPreference",
+			ExpectedError: ErrInvalidOTLPMetricsTemporalityPreference,
+		},
+		{
+			Name:          "invalid default histogram aggregation",
+			Config:        OTLPConfig{OtlpMetricsDefaultHistogramAggregation: OTELMetricAggregationSum},
+			ExpectedPath:  "otlpMetricsDefaultHistogramAggregation",
+			ExpectedError: ErrInvalidOTELMetricAggregationType,
+		},
+		{
+			Name:          "invalid metrics exporter",
+			Config:        OTLPConfig{MetricsExporter: "graphite"},
+			ExpectedPath:  "metricsExporter",
+			ExpectedError: ErrInvalidOTELMetricExporterType,
+		},
+		{
+			Name:          "invalid logs exporter",
+			Config:        OTLPConfig{LogsExporter: "syslog"},
+			ExpectedPath:  "logsExporter",
+			ExpectedError: ErrInvalidOTELLogsExporterType,
+		},
+		{
+			Name:          "invalid exemplar filter",
+			Config:        OTLPConfig{MetricsExemplarFilter: "parent_based"},
+			ExpectedPath:  "metricsExemplarFilter",
+			ExpectedError: ErrInvalidOTELMetricsExemplarFilterType,
+		},
+		{
+			Name:          "metrics endpoint required",
+			Config:        OTLPConfig{MetricsExporter: OTELMetricsExporterOTLP},
+			ExpectedPath:  "otlpMetricsEndpoint",
+			ExpectedError: ErrMetricsOTLPEndpointRequired,
+		},
+		{
+			Name:          "invalid statsd flavor",
+			Config:        OTLPConfig{StatsdFlavor: "graphite"},
+			ExpectedPath:  "statsdFlavor",
+			ExpectedError: ErrInvalidStatsdFlavor,
+		},
+		{
+			Name:          "remote write endpoint required",
+			Config:        OTLPConfig{MetricsExporter: OTELMetricsExporterPrometheusRemoteWrite},
+			ExpectedPath:  "otlpMetricsEndpoint",
+			ExpectedError: ErrMetricsOTLPEndpointRequired,
+		},
+		{
+			Name:          "invalid sampler",
+			Config:        OTLPConfig{TracesSampler: "random"},
+			ExpectedPath:  "tracesSampler",
+			ExpectedError: ErrInvalidOTELTracesSamplerType,
+		},
+		{
+			Name:          "invalid sampler argument",
+			Config:        OTLPConfig{TracesSamplerArg: float64Ptr(-0.1)},
+			ExpectedPath:  "tracesSamplerArg",
+			ExpectedError: ErrInvalidOTELTracesSamplerArg,
+		},
+		{
+			Name: "invalid propagator",
+			Config: OTLPConfig{
+				Propagators: []OTELPropagatorType{OTELPropagatorTraceContext, "xray"},
+			},
+			ExpectedPath:  "propagators[1]",
+			ExpectedError: ErrInvalidOTELPropagatorType,
+		},
+		{
+			Name: "invalid resource detector",
+			Config: OTLPConfig{
+				ResourceDetectors: []OTELResourceDetectorType{OTELResourceDetectorHost, "gcp"},
+			},
+			ExpectedPath:  "resourceDetectors[1]",
+			ExpectedError: ErrInvalidOTELResourceDetectorType,
+		},
+		{
+			Name: "invalid metric view aggregation",
+			Config: OTLPConfig{
+				MetricViews: []MetricViewConfig{
+					{InstrumentName: "http.server.request.duration", Aggregation: "summary"},
+				},
+			},
+			ExpectedPath:  "metricViews[0].aggregation",
+			ExpectedError: ErrInvalidOTELMetricAggregationType,
+		},
+		{
+			Name: "exponential histogram view of the remote-write exporter",
+			Config: OTLPConfig{
+				OtlpEndpoint:    "http://prometheus:9090",
+				MetricsExporter: OTELMetricsExporterPrometheusRemoteWrite,
+				MetricViews: []MetricViewConfig{
+					{InstrumentName: "http.client.request.duration"},
+					{
+						InstrumentName: "http.server.request.duration",
+						Aggregation:    OTELMetricAggregationBase2ExponentialBucketHistogram,
+					},
+				},
+			},
+			ExpectedPath:  "metricViews[1].aggregation",
+			ExpectedError: ErrUnsupportedRemoteWriteAggregation,
+		},
+		{
+			Name: "exponential histogram view of the OTLP exporter is valid",
+			Config: OTLPConfig{
+				OtlpEndpoint: "localhost:4317",
+				MetricViews: []MetricViewConfig{
+					{
+						InstrumentName: "http.server.request.duration",
+						Aggregation:    OTELMetricAggregationBase2ExponentialBucketHistogram,
+					},
+				},
+			},
+		},
+		{
+			Name:   "privileged prometheus port is valid",
+			Config: OTLPConfig{PrometheusPort: uintPtr(80)},
+		},
+		{
+			Name:          "invalid prometheus port",
+			Config:        OTLPConfig{PrometheusPort: uintPtr(0)},
+			ExpectedPath:  "prometheusPort",
+			ExpectedError: ErrInvalidPrometheusPort,
+		},
+		{
+			Name:          "invalid prometheus translation strategy",
+			Config:        OTLPConfig{PrometheusTranslationStrategy: "CamelCase"},
+			ExpectedPath:  "prometheusTranslationStrategy",
+			ExpectedError: ErrInvalidPrometheusTranslationStrategy,
+		},
+		{
+			Name:          "client key without certificate",
+			Config:        OTLPConfig{OtlpLogsClientKey: "client-key.pem"},
+			ExpectedPath:  "otlpLogsClientCertificate",
+			ExpectedError: ErrOTLPClientKeyPairRequired,
+		},
+		{
+			Name:          "pushgateway exporter without URL",
+			Config:        OTLPConfig{MetricsExporter: OTELMetricsExporterPushgateway, ServiceName: "batch"},
+			ExpectedPath:  "pushgatewayUrl",
+			ExpectedError: ErrPushgatewayURLRequired,
+		},
+		{
+			Name:          "invalid pushgateway URL",
+			Config:        OTLPConfig{PushgatewayURL: "ftp://pushgateway:9091"},
+			ExpectedPath:  "pushgatewayUrl",
+			ExpectedError: ErrInvalidPushgatewayURL,
+		},
+		{
+			Name:          "malformed pushgateway URL",
+			Config:        OTLPConfig{PushgatewayURL: "http://pushgateway:port"},
+			ExpectedPath:  "pushgatewayUrl",
+			ExpectedError: ErrInvalidPushgatewayURL,
+		},
+		{
+			Name:   "pushgateway URL without scheme",
+			Config: OTLPConfig{PushgatewayURL: "pushgateway:9091"},
+		},
+		{
+			Name:          "statsd address without port",
+			Config:        OTLPConfig{StatsdAddress: "statsd"},
+			ExpectedPath:  "statsdAddress",
+			ExpectedError: ErrInvalidStatsdAddress,
+		},
+		{
+			Name:          "statsd address with invalid port",
+			Config:        OTLPConfig{StatsdAddress: "statsd:99999"},
+			ExpectedPath:  "statsdAddress",
+			ExpectedError: ErrInvalidStatsdAddress,
+		},
+		{
+			Name:   "statsd address without host",
+			Config: OTLPConfig{StatsdAddress: ":8125"},
+		},
+		{
+			Name: "pushgateway exporter without job",
+			Config: OTLPConfig{
+				MetricsExporter: OTELMetricsExporterPushgateway,
+				PushgatewayURL:  "http://localhost:9091",
+			},
+			ExpectedPath:  "pushgatewayJob",
+			ExpectedError: ErrPushgatewayJobRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Config.Validate()
+			if tc.ExpectedError == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tc.ExpectedError) {
+				t.Fatalf("expected error %v, got: %v", tc.ExpectedError, err)
+			}
+
+			var fieldErr *ConfigFieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("expected ConfigFieldError, got: %T", err)
+			}
+
+			if fieldErr.Path != tc.ExpectedPath {
+				t.Errorf("expected path %s, got %s", tc.ExpectedPath, fieldErr.Path)
+			}
+		})
+	}
+}
+
+func TestOTLPConfig_ValidateJoinsErrors(t *testing.T) {
+	config := OTLPConfig{
+		OtlpProtocol:   "http/json",
+		TracesExporter: "zipkin",
+		LogsExporter:   "syslog",
+	}
+
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("expected error but got none")
+	}
+
+	joinedErr, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected joined error, got %T", err)
+	}
+
+	if len(joinedErr.Unwrap()) != 3 {
+		t.Errorf("expected 3 errors, got %d: %v", len(joinedErr.Unwrap()), err)
+	}
+
+	expectedMessage := "otlpProtocol: invalid OTLP protocol: http/json"
+	if joinedErr.Unwrap()[0].Error() != expectedMessage {
+		t.Errorf("expected message %q, got %q", expectedMessage, joinedErr.Unwrap()[0].Error())
+	}
+}
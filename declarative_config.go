@@ -0,0 +1,1073 @@
+package gotel
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	declarativeServiceNameAttribute       = "service.name"
+	declarativeServiceNamespaceAttribute  = "service.namespace"
+	declarativeServiceInstanceIDAttribute = "service.instance.id"
+)
+
+// matches ${VAR}, ${env:VAR}, ${VAR:-default} and the $$ escape sequence.
+var declarativeEnvSubstitutionRegex = regexp.MustCompile(
+	`\$\$|\$\{(?:env:)?([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`,
+)
+
+// declarativeConfig is the subset of the OpenTelemetry declarative configuration file format
+// that gotel supports. Other fields are rejected, see [checkDeclarativeFields].
+type declarativeConfig struct {
+	FileFormat     string                     `yaml:"file_format"`
+	Disabled       bool                       `yaml:"disabled"`
+	Resource       *declarativeResource       `yaml:"resource"`
+	Propagator     *declarativePropagator     `yaml:"propagator"`
+	TracerProvider *declarativeTracerProvider `yaml:"tracer_provider"`
+	MeterProvider  *declarativeMeterProvider  `yaml:"meter_provider"`
+	LoggerProvider *declarativeLoggerProvider `yaml:"logger_provider"`
+}
+
+type declarativeNameValue struct {
+	Name  string `yaml:"name"`
+	Value any    `yaml:"value"`
+	Type  string `yaml:"type"`
+}
+
+type declarativeResource struct {
+	Attributes     []declarativeNameValue        `yaml:"attributes"`
+	AttributesList string                        `yaml:"attributes_list"`
+	Detection      *declarativeResourceDetection `yaml:"detection/development"`
+}
+
+type declarativeResourceDetection struct {
+	Detectors []declarativeResourceDetectorName `yaml:"detectors"`
+}
+
+// declarativeResourceDetectorName is the key of a single-key resource detector object, e.g. container: {}.
+type declarativeResourceDetectorName string
+
+// format the scalar value as a string. Array values are not supported because
+// resource attributes and headers of OTLPConfig are strings.
+func (dnv declarativeNameValue) formatValue() (string, error) {
+	if strings.HasSuffix(dnv.Type, "_array") {
+		return "", fmt.Errorf("%w: %s value of %s", ErrUnsupportedDeclarativeConfig, dnv.Type, dnv.Name)
+	}
+
+	switch value := dnv.Value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("%w: non-scalar value of %s", ErrUnsupportedDeclarativeConfig, dnv.Name)
+	}
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (drdn *declarativeResourceDetectorName) UnmarshalYAML(node *yaml.Node) error {
+	name, valueNode, err := decodeDeclarativeSingleKey(node)
+	if err != nil {
+		return err
+	}
+
+	*drdn = declarativeResourceDetectorName(name)
+
+	return checkDeclarativeFields(valueNode, reflect.TypeFor[struct{}]())
+}
+
+type declarativePropagator struct {
+	Composite     []declarativePropagatorName `yaml:"composite"`
+	CompositeList string                      `yaml:"composite_list"`
+}
+
+// declarativePropagatorName accepts both the string list (file_format 0.x)
+// and the list of single-key objects (file_format 1.x) of composite propagators.
+type declarativePropagatorName string
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (dpn *declarativePropagatorName) UnmarshalYAML(node *yaml.Node) error {
+	name, valueNode, err := decodeDeclarativeSingleKey(node)
+	if err != nil {
+		return err
+	}
+
+	*dpn = declarativePropagatorName(name)
+
+	return checkDeclarativeFields(valueNode, reflect.TypeFor[struct{}]())
+}
+
+type declarativeTracerProvider struct {
+	Processors []declarativeProcessor `yaml:"processors"`
+	Sampler    *declarativeSampler    `yaml:"sampler"`
+}
+
+type declarativeLoggerProvider struct {
+	Processors []declarativeProcessor `yaml:"processors"`
+}
+
+type declarativeProcessor struct {
+	Batch  *declarativeBatchProcessor `yaml:"batch"`
+	Simple *declarativeBatchProcessor `yaml:"simple"`
+}
+
+type declarativeBatchProcessor struct {
+	ScheduleDelay      *uint               `yaml:"schedule_delay"`
+	ExportTimeout      *uint               `yaml:"export_timeout"`
+	MaxQueueSize       *uint               `yaml:"max_queue_size"`
+	MaxExportBatchSize *uint               `yaml:"max_export_batch_size"`
+	Exporter           declarativeExporter `yaml:"exporter"`
+}
+
+type declarativeMeterProvider struct {
+	Readers        []declarativeMetricReader     `yaml:"readers"`
+	Views          []declarativeView             `yaml:"views"`
+	ExemplarFilter OTELMetricsExemplarFilterType `yaml:"exemplar_filter"`
+}
+
+type declarativeView struct {
+	Selector declarativeViewSelector `yaml:"selector"`
+	Stream   declarativeViewStream   `yaml:"stream"`
+}
+
+type declarativeViewSelector struct {
+	InstrumentName string `yaml:"instrument_name"`
+	InstrumentType string `yaml:"instrument_type"`
+	Unit           string `yaml:"unit"`
+	MeterName      string `yaml:"meter_name"`
+	MeterVersion   string `yaml:"meter_version"`
+	MeterSchemaURL string `yaml:"meter_schema_url"`
+}
+
+type declarativeViewStream struct {
+	Name          string                    `yaml:"name"`
+	Description   string                    `yaml:"description"`
+	Aggregation   *declarativeAggregation   `yaml:"aggregation"`
+	AttributeKeys *declarativeAttributeKeys `yaml:"attribute_keys"`
+}
+
+// declarativeAggregation is a single-key object whose key is the aggregation type.
+type declarativeAggregation struct {
+	Type       string
+	Boundaries []float64
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (da *declarativeAggregation) UnmarshalYAML(node *yaml.Node) error {
+	aggregationType, valueNode, err := decodeDeclarativeSingleKey(node)
+	if err != nil {
+		return err
+	}
+
+	da.Type = aggregationType
+
+	if aggregationType != string(OTELMetricAggregationExplicitBucketHistogram) {
+		return checkDeclarativeFields(valueNode, reflect.TypeFor[struct{}]())
+	}
+
+	var options struct {
+		Boundaries []float64 `yaml:"boundaries"`
+	}
+
+	err = decodeDeclarativeNode(valueNode, &options)
+	if err != nil {
+		return err
+	}
+
+	da.Boundaries = options.Boundaries
+
+	return nil
+}
+
+// declarativeAttributeKeys accepts both the key list (file_format 0.x)
+// and the object of included and excluded keys (file_format 1.x).
+type declarativeAttributeKeys struct {
+	Included []string `yaml:"included"`
+	Excluded []string `yaml:"excluded"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (dak *declarativeAttributeKeys) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode(&dak.Included)
+	}
+
+	type rawAttributeKeys declarativeAttributeKeys
+
+	return decodeDeclarativeNode(node, (*rawAttributeKeys)(dak))
+}
+
+type declarativeMetricReader struct {
+	Periodic *declarativePeriodicReader `yaml:"periodic"`
+	Pull     *declarativePullReader     `yaml:"pull"`
+}
+
+type declarativePeriodicReader struct {
+	Interval          *uint               `yaml:"interval"`
+	Timeout           *uint               `yaml:"timeout"`
+	Exporter          declarativeExporter `yaml:"exporter"`
+	CardinalityLimits map[string]int      `yaml:"cardinality_limits"`
+}
+
+type declarativePullReader struct {
+	Exporter          declarativeExporter `yaml:"exporter"`
+	CardinalityLimits map[string]int      `yaml:"cardinality_limits"`
+}
+
+// declarativeExporter is a single-key object whose key is the exporter type.
+type declarativeExporter struct {
+	Type       string
+	OTLP       *declarativeOTLPExporter
+	File       *declarativeFileExporter
+	Prometheus *declarativePrometheusExporter
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (de *declarativeExporter) UnmarshalYAML(node *yaml.Node) error {
+	exporterType, valueNode, err := decodeDeclarativeSingleKey(node)
+	if err != nil {
+		return err
+	}
+
+	de.Type = exporterType
+
+	switch exporterType {
+	case "otlp", "otlp_http", "otlp_grpc":
+		de.OTLP = &declarativeOTLPExporter{}
+
+		return decodeDeclarativeNode(valueNode, de.OTLP)
+	case "otlp_file", "otlp_file/development":
+		de.File = &declarativeFileExporter{}
+
+		return decodeDeclarativeNode(valueNode, de.File)
+	case "prometheus", "prometheus/development":
+		de.Prometheus = &declarativePrometheusExporter{}
+
+		return decodeDeclarativeNode(valueNode, de.Prometheus)
+	case "console":
+		return checkDeclarativeFields(valueNode, reflect.TypeFor[struct{}]())
+	default:
+		// unsupported exporters are rejected by the provider.
+		return nil
+	}
+}
+
+type declarativeOTLPExporter struct {
+	Protocol          OTLPProtocol           `yaml:"protocol"`
+	Endpoint          string                 `yaml:"endpoint"`
+	Certificate       string                 `yaml:"certificate"`
+	ClientKey         string                 `yaml:"client_key"`
+	ClientCertificate string                 `yaml:"client_certificate"`
+	Headers           []declarativeNameValue `yaml:"headers"`
+	HeadersList       string                 `yaml:"headers_list"`
+	Compression       OTLPCompressionType    `yaml:"compression"`
+	Timeout           *uint                  `yaml:"timeout"`
+	Insecure          *bool                  `yaml:"insecure"`
+	TLS               *declarativeTLS        `yaml:"tls"`
+	// metrics exporter only.
+	TemporalityPreference       OTLPMetricsTemporalityPreference `yaml:"temporality_preference"`
+	DefaultHistogramAggregation OTELMetricAggregationType        `yaml:"default_histogram_aggregation"`
+}
+
+type declarativeTLS struct {
+	CAFile   string `yaml:"ca_file"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	Insecure *bool  `yaml:"insecure"`
+}
+
+type declarativeFileExporter struct {
+	OutputStream string `yaml:"output_stream"`
+}
+
+type declarativePrometheusExporter struct {
+	Host                string                        `yaml:"host"`
+	Port                *uint                         `yaml:"port"`
+	WithoutScopeInfo    *bool                         `yaml:"without_scope_info"`
+	WithoutTargetInfo   *bool                         `yaml:"without_target_info"`
+	TranslationStrategy PrometheusTranslationStrategy `yaml:"translation_strategy"`
+}
+
+// declarativeSampler is a single-key object whose key is the sampler type.
+type declarativeSampler struct {
+	Type        string
+	Ratio       *float64
+	ParentBased declarativeParentBasedSampler
+}
+
+// declarativeParentBasedSampler contains the delegate samplers of the parent_based sampler.
+type declarativeParentBasedSampler struct {
+	Root                   *declarativeSampler `yaml:"root"`
+	RemoteParentSampled    *declarativeSampler `yaml:"remote_parent_sampled"`
+	RemoteParentNotSampled *declarativeSampler `yaml:"remote_parent_not_sampled"`
+	LocalParentSampled     *declarativeSampler `yaml:"local_parent_sampled"`
+	LocalParentNotSampled  *declarativeSampler `yaml:"local_parent_not_sampled"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ds *declarativeSampler) UnmarshalYAML(node *yaml.Node) error {
+	samplerType, valueNode, err := decodeDeclarativeSingleKey(node)
+	if err != nil {
+		return err
+	}
+
+	ds.Type = samplerType
+
+	switch samplerType {
+	case "always_on", "always_off":
+		return checkDeclarativeFields(valueNode, reflect.TypeFor[struct{}]())
+	case "trace_id_ratio_based":
+		var options struct {
+			Ratio *float64 `yaml:"ratio"`
+		}
+
+		err = decodeDeclarativeNode(valueNode, &options)
+		ds.Ratio = options.Ratio
+
+		return err
+	case "parent_based":
+		return decodeDeclarativeNode(valueNode, &ds.ParentBased)
+	default:
+		// unsupported samplers are rejected by the tracer provider.
+		return nil
+	}
+}
+
+// otlpSignalFields holds pointers to the signal-specific OTLP fields of an [OTLPConfig].
+type otlpSignalFields struct {
+	endpoint          *string
+	protocol          *OTLPProtocol
+	compression       *OTLPCompressionType
+	headers           *map[string]string
+	timeout           **uint
+	insecure          **bool
+	certificate       *string
+	clientCertificate *string
+	clientKey         *string
+}
+
+// LoadDeclarativeConfig loads an OpenTelemetry declarative configuration file and converts it to [OTLPConfig].
+// Environment variable references such as ${VAR} and ${VAR:-default} are substituted before decoding.
+// Other environment variables are ignored, following the declarative configuration specification.
+//
+// Only the subset that maps to [OTLPConfig] is supported: one processor or reader per signal with
+// otlp, otlp_http, otlp_grpc, console, otlp_file/development or prometheus exporters,
+// the built-in samplers, propagators and the service.name resource attribute.
+// Other options return an error that wraps [ErrUnsupportedDeclarativeConfig] instead of being ignored.
+func LoadDeclarativeConfig(filePath string) (*OTLPConfig, error) {
+	rawBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the declarative config file: %w", err)
+	}
+
+	return parseDeclarativeConfig(filePath, rawBytes)
+}
+
+func parseDeclarativeConfig(filePath string, rawBytes []byte) (*OTLPConfig, error) {
+	var (
+		document  yaml.Node
+		rawConfig declarativeConfig
+	)
+
+	err := yaml.Unmarshal(substituteDeclarativeEnv(rawBytes), &document)
+	if err == nil && len(document.Content) > 0 {
+		err = decodeDeclarativeNode(document.Content[0], &rawConfig)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the declarative config file %s: %w", filePath, err)
+	}
+
+	config, err := rawConfig.toOTLPConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyOTLPConfigDefaults(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// returns true if the file content has the file_format field of the declarative configuration.
+func isDeclarativeConfig(rawBytes []byte) bool {
+	var header struct {
+		FileFormat string `yaml:"file_format"`
+	}
+
+	return yaml.Unmarshal(rawBytes, &header) == nil && header.FileFormat != ""
+}
+
+func substituteDeclarativeEnv(rawBytes []byte) []byte {
+	return declarativeEnvSubstitutionRegex.ReplaceAllFunc(rawBytes, func(match []byte) []byte {
+		if string(match) == "$$" {
+			return []byte("$")
+		}
+
+		groups := declarativeEnvSubstitutionRegex.FindSubmatch(match)
+
+		value, ok := os.LookupEnv(string(groups[1]))
+		if !ok || value == "" {
+			return groups[2]
+		}
+
+		return []byte(value)
+	})
+}
+
+func (dc declarativeConfig) toOTLPConfig() (*OTLPConfig, error) {
+	if !strings.HasPrefix(dc.FileFormat, "0.") && !strings.HasPrefix(dc.FileFormat, "1.") {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDeclarativeFileFormat, dc.FileFormat)
+	}
+
+	config := &OTLPConfig{
+		TracesExporter:  OTELTracesExporterNone,
+		MetricsExporter: OTELMetricsExporterNone,
+		LogsExporter:    OTELLogsExporterNone,
+	}
+
+	errs := []error{}
+
+	if dc.Resource != nil {
+		errs = append(errs, dc.Resource.applyAttributes(config))
+
+		if dc.Resource.Detection != nil {
+			config.ResourceDetectors = dc.Resource.Detection.toResourceDetectors()
+		}
+	}
+
+	// propagation is disabled if the propagator is not configured, following the specification.
+	config.Propagators = []OTELPropagatorType{OTELPropagatorNone}
+
+	if dc.Propagator != nil {
+		config.Propagators = dc.Propagator.toPropagators()
+	}
+
+	if dc.Disabled {
+		config.SdkDisabled = &dc.Disabled
+
+		return config, errors.Join(errs...)
+	}
+
+	errs = append(
+		errs,
+		dc.applyTracerProvider(config),
+		dc.applyMeterProvider(config),
+		dc.applyMetricViews(config),
+		dc.applyLoggerProvider(config),
+	)
+
+	return config, errors.Join(errs...)
+}
+
+func (dp declarativePropagator) toPropagators() []OTELPropagatorType {
+	results := []OTELPropagatorType{}
+
+	for _, name := range dp.Composite {
+		results = append(results, OTELPropagatorType(name))
+	}
+
+	for _, name := range splitConfigValues(dp.CompositeList, defaultEnvSeparator) {
+		propagator := OTELPropagatorType(name)
+		if !slices.Contains(results, propagator) {
+			results = append(results, propagator)
+		}
+	}
+
+	// an empty propagator list is configured explicitly, so it disables propagation.
+	if len(results) == 0 {
+		return []OTELPropagatorType{OTELPropagatorNone}
+	}
+
+	return results
+}
+
+// apply resource attributes to the configuration. Service attributes are mapped to the service fields.
+func (dr declarativeResource) applyAttributes(config *OTLPConfig) error {
+	attrs, err := newDeclarativeKeyValues("resource attributes", dr.AttributesList, dr.Attributes)
+	if err != nil {
+		return err
+	}
+
+	for key, field := range map[string]*string{
+		declarativeServiceNameAttribute:       &config.ServiceName,
+		declarativeServiceNamespaceAttribute:  &config.ServiceNamespace,
+		declarativeServiceInstanceIDAttribute: &config.ServiceInstanceID,
+	} {
+		if value, ok := attrs[key]; ok {
+			*field = value
+
+			delete(attrs, key)
+		}
+	}
+
+	if len(attrs) > 0 {
+		config.ResourceAttributes = attrs
+	}
+
+	return nil
+}
+
+func (drd declarativeResourceDetection) toResourceDetectors() []OTELResourceDetectorType {
+	results := []OTELResourceDetectorType{}
+
+	for _, name := range drd.Detectors {
+		results = append(results, OTELResourceDetectorType(name))
+	}
+
+	// an empty detector list is configured explicitly, so it disables detection.
+	if len(results) == 0 {
+		return []OTELResourceDetectorType{OTELResourceDetectorNone}
+	}
+
+	return results
+}
+
+func (dc declarativeConfig) applyTracerProvider(config *OTLPConfig) error {
+	if dc.TracerProvider == nil {
+		return nil
+	}
+
+	if dc.TracerProvider.Sampler != nil {
+		samplerType, ratio, err := dc.TracerProvider.Sampler.toTracesSampler()
+		if err != nil {
+			return err
+		}
+
+		config.TracesSampler = samplerType
+		config.TracesSamplerArg = ratio
+	}
+
+	processor, err := getDeclarativeBatchProcessor("tracer_provider", dc.TracerProvider.Processors)
+	if err != nil || processor == nil {
+		return err
+	}
+
+	config.TracesMaxQueueSize = processor.MaxQueueSize
+	config.TracesMaxExportBatchSize = processor.MaxExportBatchSize
+	config.TracesExportTimeout = processor.ExportTimeout
+	config.TracesScheduleDelay = processor.ScheduleDelay
+
+	exporter := processor.Exporter
+
+	switch {
+	case exporter.Type == "console":
+		config.TracesExporter = OTELTracesExporterConsole
+	case exporter.OTLP != nil:
+		config.TracesExporter = OTELTracesExporterOTLP
+
+		return exporter.applyOTLP(config.tracesSignalFields())
+	case exporter.File != nil && exporter.File.isStdout():
+		config.TracesExporter = OTELTracesExporterConsole
+	case exporter.File != nil:
+		config.TracesExporter = OTELTracesExporterFile
+		config.TracesFilePath = exporter.File.filePath()
+	default:
+		return fmt.Errorf("%w: tracer_provider exporter %s", ErrUnsupportedDeclarativeConfig, exporter.Type)
+	}
+
+	return nil
+}
+
+func (dc declarativeConfig) applyMeterProvider(config *OTLPConfig) error {
+	if dc.MeterProvider == nil {
+		return nil
+	}
+
+	config.MetricsExemplarFilter = dc.MeterProvider.ExemplarFilter
+
+	if len(dc.MeterProvider.Readers) == 0 {
+		return nil
+	}
+
+	if len(dc.MeterProvider.Readers) > 1 {
+		return fmt.Errorf("%w: meter_provider supports one reader only", ErrUnsupportedDeclarativeConfig)
+	}
+
+	reader := dc.MeterProvider.Readers[0]
+
+	if reader.Pull != nil {
+		err := applyCardinalityLimits(config, reader.Pull.CardinalityLimits)
+		if err != nil {
+			return err
+		}
+
+		if reader.Pull.Exporter.Prometheus == nil {
+			return fmt.Errorf(
+				"%w: meter_provider pull exporter %s",
+				ErrUnsupportedDeclarativeConfig,
+				reader.Pull.Exporter.Type,
+			)
+		}
+
+		prometheusExporter := reader.Pull.Exporter.Prometheus
+
+		// the Prometheus server listens on all interfaces.
+		if !slices.Contains([]string{"", "0.0.0.0", "::"}, prometheusExporter.Host) {
+			return fmt.Errorf(
+				"%w: prometheus exporter host %s, the server listens on all interfaces",
+				ErrUnsupportedDeclarativeConfig,
+				prometheusExporter.Host,
+			)
+		}
+
+		config.MetricsExporter = OTELMetricsExporterPrometheus
+		config.PrometheusPort = prometheusExporter.Port
+		config.PrometheusWithoutScopeInfo = prometheusExporter.WithoutScopeInfo
+		config.PrometheusWithoutTargetInfo = prometheusExporter.WithoutTargetInfo
+		config.PrometheusTranslationStrategy = prometheusExporter.TranslationStrategy
+
+		return nil
+	}
+
+	if reader.Periodic == nil {
+		return fmt.Errorf("%w: meter_provider reader must be periodic or pull", ErrUnsupportedDeclarativeConfig)
+	}
+
+	err := applyCardinalityLimits(config, reader.Periodic.CardinalityLimits)
+	if err != nil {
+		return err
+	}
+
+	config.MetricsExportInterval = reader.Periodic.Interval
+	config.MetricsExportTimeout = reader.Periodic.Timeout
+
+	exporter := reader.Periodic.Exporter
+
+	switch {
+	case exporter.Type == "console":
+		config.MetricsExporter = OTELMetricsExporterConsole
+	case exporter.OTLP != nil:
+		config.MetricsExporter = OTELMetricsExporterOTLP
+		config.OtlpMetricsTemporalityPreference = exporter.OTLP.TemporalityPreference
+		config.OtlpMetricsDefaultHistogramAggregation = exporter.OTLP.DefaultHistogramAggregation
+
+		return exporter.applyOTLP(config.metricsSignalFields())
+	case exporter.File != nil && exporter.File.isStdout():
+		config.MetricsExporter = OTELMetricsExporterConsole
+	case exporter.File != nil:
+		config.MetricsExporter = OTELMetricsExporterFile
+		config.MetricsFilePath = exporter.File.filePath()
+	default:
+		return fmt.Errorf("%w: meter_provider periodic exporter %s", ErrUnsupportedDeclarativeConfig, exporter.Type)
+	}
+
+	return nil
+}
+
+// apply the default cardinality limit of the metric reader.
+// Limits per instrument kind are not supported because the limit applies to all instruments.
+func applyCardinalityLimits(config *OTLPConfig, limits map[string]int) error {
+	for key, limit := range limits {
+		if key != "default" {
+			return fmt.Errorf("%w: cardinality limit of %s instruments", ErrUnsupportedDeclarativeConfig, key)
+		}
+
+		config.MetricsCardinalityLimit = &limit
+	}
+
+	return nil
+}
+
+func (dc declarativeConfig) applyMetricViews(config *OTLPConfig) error {
+	if dc.MeterProvider == nil {
+		return nil
+	}
+
+	errs := []error{}
+
+	for i, view := range dc.MeterProvider.Views {
+		viewConfig, err := view.toMetricViewConfig()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("meter_provider views[%d]: %w", i, err))
+
+			continue
+		}
+
+		config.MetricViews = append(config.MetricViews, viewConfig)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (dv declarativeView) toMetricViewConfig() (MetricViewConfig, error) {
+	selector := dv.Selector
+	if selector.InstrumentType != "" || selector.Unit != "" || selector.MeterVersion != "" ||
+		selector.MeterSchemaURL != "" {
+		return MetricViewConfig{}, fmt.Errorf(
+			"%w: view selectors support instrument_name and meter_name only",
+			ErrUnsupportedDeclarativeConfig,
+		)
+	}
+
+	result := MetricViewConfig{
+		InstrumentName: selector.InstrumentName,
+		MeterName:      selector.MeterName,
+		Name:           dv.Stream.Name,
+		Description:    dv.Stream.Description,
+	}
+
+	if dv.Stream.AttributeKeys != nil {
+		if len(dv.Stream.AttributeKeys.Excluded) > 0 {
+			return MetricViewConfig{}, fmt.Errorf(
+				"%w: excluded attribute keys of views",
+				ErrUnsupportedDeclarativeConfig,
+			)
+		}
+
+		result.AttributeKeys = dv.Stream.AttributeKeys.Included
+	}
+
+	if dv.Stream.Aggregation != nil {
+		result.Aggregation = OTELMetricAggregationType(dv.Stream.Aggregation.Type)
+		result.Buckets = dv.Stream.Aggregation.Boundaries
+
+		// boundaries of the declarative explicit bucket histogram default to the boundaries of the specification.
+		if result.Aggregation == OTELMetricAggregationExplicitBucketHistogram && result.Buckets == nil {
+			result.Buckets = slices.Clone(defaultHistogramBuckets)
+		}
+	}
+
+	return result, nil
+}
+
+func (dc declarativeConfig) applyLoggerProvider(config *OTLPConfig) error {
+	if dc.LoggerProvider == nil {
+		return nil
+	}
+
+	processor, err := getDeclarativeBatchProcessor("logger_provider", dc.LoggerProvider.Processors)
+	if err != nil || processor == nil {
+		return err
+	}
+
+	config.LogsMaxQueueSize = processor.MaxQueueSize
+	config.LogsMaxExportBatchSize = processor.MaxExportBatchSize
+	config.LogsExportTimeout = processor.ExportTimeout
+	config.LogsScheduleDelay = processor.ScheduleDelay
+
+	exporter := processor.Exporter
+
+	switch {
+	case exporter.Type == "console":
+		config.LogsExporter = OTELLogsExporterConsole
+	case exporter.OTLP != nil:
+		config.LogsExporter = OTELLogsExporterOTLP
+
+		return exporter.applyOTLP(config.logsSignalFields())
+	case exporter.File != nil && exporter.File.isStdout():
+		config.LogsExporter = OTELLogsExporterConsole
+	case exporter.File != nil:
+		config.LogsExporter = OTELLogsExporterFile
+		config.LogsFilePath = exporter.File.filePath()
+	default:
+		return fmt.Errorf("%w: logger_provider exporter %s", ErrUnsupportedDeclarativeConfig, exporter.Type)
+	}
+
+	return nil
+}
+
+func getDeclarativeBatchProcessor(
+	provider string,
+	processors []declarativeProcessor,
+) (*declarativeBatchProcessor, error) {
+	switch len(processors) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("%w: %s supports one processor only", ErrUnsupportedDeclarativeConfig, provider)
+	}
+
+	if processors[0].Batch == nil {
+		return nil, fmt.Errorf("%w: %s supports the batch processor only", ErrUnsupportedDeclarativeConfig, provider)
+	}
+
+	return processors[0].Batch, nil
+}
+
+func (de declarativeExporter) applyOTLP(fields otlpSignalFields) error {
+	otlp := de.OTLP
+
+	*fields.endpoint = otlp.Endpoint
+	*fields.compression = otlp.Compression
+	*fields.timeout = otlp.Timeout
+	*fields.insecure = otlp.Insecure
+	*fields.certificate = otlp.Certificate
+	*fields.clientCertificate = otlp.ClientCertificate
+	*fields.clientKey = otlp.ClientKey
+
+	switch de.Type {
+	case "otlp_grpc":
+		*fields.protocol = OTLPProtocolGRPC
+	case "otlp_http":
+		*fields.protocol = OTLPProtocolHTTPProtobuf
+	default:
+		*fields.protocol = otlp.Protocol
+	}
+
+	if otlp.TLS != nil {
+		*fields.certificate = getDefault(otlp.TLS.CAFile, otlp.Certificate)
+		*fields.clientCertificate = getDefault(otlp.TLS.CertFile, otlp.ClientCertificate)
+		*fields.clientKey = getDefault(otlp.TLS.KeyFile, otlp.ClientKey)
+		*fields.insecure = getDefaultPtr(otlp.TLS.Insecure, otlp.Insecure)
+	}
+
+	headers, err := newDeclarativeKeyValues("headers", otlp.HeadersList, otlp.Headers)
+	if err != nil {
+		return err
+	}
+
+	if len(headers) > 0 {
+		*fields.headers = headers
+	}
+
+	return nil
+}
+
+// merge the name-value pairs and the comma-separated list of percent-encoded key=value pairs.
+// Name-value pairs take precedence over the list.
+func newDeclarativeKeyValues(field string, list string, items []declarativeNameValue) (map[string]string, error) {
+	results := map[string]string{}
+
+	for _, item := range splitConfigValues(list, defaultEnvSeparator) {
+		key, value, err := parseConfigKeyValue(item, defaultEnvKeyValSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the %s list: %w", field, err)
+		}
+
+		results[key] = value
+	}
+
+	for _, item := range items {
+		value, err := item.formatValue()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+
+		results[item.Name] = value
+	}
+
+	return results, nil
+}
+
+func (ds declarativeSampler) toTracesSampler() (OTELTracesSamplerType, *float64, error) {
+	switch ds.Type {
+	case "always_on":
+		return OTELTracesSamplerAlwaysOn, nil, nil
+	case "always_off":
+		return OTELTracesSamplerAlwaysOff, nil, nil
+	case "trace_id_ratio_based":
+		return OTELTracesSamplerTraceIDRatio, ds.Ratio, nil
+	case "parent_based":
+		err := ds.ParentBased.validateDelegates()
+		if err != nil {
+			return "", nil, err
+		}
+
+		root := ds.ParentBased.Root
+		if root == nil {
+			return OTELTracesSamplerParentBasedAlwaysOn, nil, nil
+		}
+
+		switch root.Type {
+		case "always_on":
+			return OTELTracesSamplerParentBasedAlwaysOn, nil, nil
+		case "always_off":
+			return OTELTracesSamplerParentBasedAlwaysOff, nil, nil
+		case "trace_id_ratio_based":
+			return OTELTracesSamplerParentBasedTraceIDRatio, root.Ratio, nil
+		}
+
+		return "", nil, fmt.Errorf("%w: parent_based root sampler %s", ErrUnsupportedDeclarativeConfig, root.Type)
+	default:
+		return "", nil, fmt.Errorf("%w: sampler %s", ErrUnsupportedDeclarativeConfig, ds.Type)
+	}
+}
+
+// validate that the samplers of sampled and not sampled parents are the defaults of the parent-based samplers,
+// i.e. always_on and always_off respectively.
+func (dpbs declarativeParentBasedSampler) validateDelegates() error {
+	errs := []error{}
+
+	for name, delegate := range map[string]struct {
+		sampler  *declarativeSampler
+		expected string
+	}{
+		"remote_parent_sampled":     {dpbs.RemoteParentSampled, "always_on"},
+		"remote_parent_not_sampled": {dpbs.RemoteParentNotSampled, "always_off"},
+		"local_parent_sampled":      {dpbs.LocalParentSampled, "always_on"},
+		"local_parent_not_sampled":  {dpbs.LocalParentNotSampled, "always_off"},
+	} {
+		if delegate.sampler != nil && delegate.sampler.Type != delegate.expected {
+			errs = append(errs, fmt.Errorf(
+				"%w: parent_based %s sampler %s, only %s is supported",
+				ErrUnsupportedDeclarativeConfig,
+				name,
+				delegate.sampler.Type,
+				delegate.expected,
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (dfe declarativeFileExporter) isStdout() bool {
+	return dfe.OutputStream == "stdout"
+}
+
+func (dfe declarativeFileExporter) filePath() string {
+	return strings.TrimPrefix(dfe.OutputStream, "file://")
+}
+
+func (oc *OTLPConfig) tracesSignalFields() otlpSignalFields {
+	return otlpSignalFields{
+		endpoint:          &oc.OtlpTracesEndpoint,
+		protocol:          &oc.OtlpTracesProtocol,
+		compression:       &oc.OtlpTracesCompression,
+		headers:           &oc.OtlpTracesHeaders,
+		timeout:           &oc.OtlpTracesTimeout,
+		insecure:          &oc.OtlpTracesInsecure,
+		certificate:       &oc.OtlpTracesCertificate,
+		clientCertificate: &oc.OtlpTracesClientCertificate,
+		clientKey:         &oc.OtlpTracesClientKey,
+	}
+}
+
+func (oc *OTLPConfig) metricsSignalFields() otlpSignalFields {
+	return otlpSignalFields{
+		endpoint:          &oc.OtlpMetricsEndpoint,
+		protocol:          &oc.OtlpMetricsProtocol,
+		compression:       &oc.OtlpMetricsCompression,
+		headers:           &oc.OtlpMetricsHeaders,
+		timeout:           &oc.OtlpMetricsTimeout,
+		insecure:          &oc.OtlpMetricsInsecure,
+		certificate:       &oc.OtlpMetricsCertificate,
+		clientCertificate: &oc.OtlpMetricsClientCertificate,
+		clientKey:         &oc.OtlpMetricsClientKey,
+	}
+}
+
+func (oc *OTLPConfig) logsSignalFields() otlpSignalFields {
+	return otlpSignalFields{
+		endpoint:          &oc.OtlpLogsEndpoint,
+		protocol:          &oc.OtlpLogsProtocol,
+		compression:       &oc.OtlpLogsCompression,
+		headers:           &oc.OtlpLogsHeaders,
+		timeout:           &oc.OtlpLogsTimeout,
+		insecure:          &oc.OtlpLogsInsecure,
+		certificate:       &oc.OtlpLogsCertificate,
+		clientCertificate: &oc.OtlpLogsClientCertificate,
+		clientKey:         &oc.OtlpLogsClientKey,
+	}
+}
+
+// decode the key and the value node of a single-key object.
+// A scalar node is treated as a key without options.
+func decodeDeclarativeSingleKey(node *yaml.Node) (string, *yaml.Node, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value, &yaml.Node{Kind: yaml.MappingNode}, nil
+	case yaml.MappingNode:
+		if len(node.Content) != 2 { //nolint:mnd
+			return "", nil, fmt.Errorf(
+				"%w: expected an object with exactly one key at line %d",
+				ErrUnsupportedDeclarativeConfig,
+				node.Line,
+			)
+		}
+
+		return node.Content[0].Value, node.Content[1], nil
+	default:
+		return "", nil, fmt.Errorf("%w: unexpected value at line %d", ErrUnsupportedDeclarativeConfig, node.Line)
+	}
+}
+
+// check the fields of the node and decode it to the target.
+func decodeDeclarativeNode(node *yaml.Node, target any) error {
+	err := checkDeclarativeFields(node, reflect.TypeOf(target).Elem())
+	if err != nil {
+		return err
+	}
+
+	return node.Decode(target)
+}
+
+// return an error of every key of mapping nodes that is not a yaml field of the target type,
+// so options that cannot be mapped to OTLPConfig are not dropped silently.
+// Fields of types that implement yaml.Unmarshaler are checked by their UnmarshalYAML method.
+func checkDeclarativeFields(node *yaml.Node, targetType reflect.Type) error {
+	for targetType.Kind() == reflect.Pointer {
+		targetType = targetType.Elem()
+	}
+
+	if reflect.PointerTo(targetType).Implements(reflect.TypeFor[yaml.Unmarshaler]()) {
+		return nil
+	}
+
+	switch {
+	case targetType.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		errs := []error{}
+
+		for _, item := range node.Content {
+			errs = append(errs, checkDeclarativeFields(item, targetType.Elem()))
+		}
+
+		return errors.Join(errs...)
+	case targetType.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		errs := []error{}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+
+			field, ok := getDeclarativeField(targetType, keyNode.Value)
+			if !ok {
+				errs = append(errs, fmt.Errorf(
+					"%w: field %s at line %d",
+					ErrUnsupportedDeclarativeConfig,
+					keyNode.Value,
+					keyNode.Line,
+				))
+
+				continue
+			}
+
+			errs = append(errs, checkDeclarativeFields(node.Content[i+1], field.Type))
+		}
+
+		return errors.Join(errs...)
+	default:
+		return nil
+	}
+}
+
+// find the struct field by the name of its yaml tag.
+func getDeclarativeField(structType reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+
+		tagName, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if tagName == name {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
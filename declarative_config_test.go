@@ -0,0 +1,692 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadDeclarativeConfig(t *testing.T) {
+	t.Run("loads file format 0.x", func(t *testing.T) {
+		t.Setenv("TEST_OTLP_API_KEY", "secret")
+
+		filePath := writeTestConfigFile(t, "otel.yaml", `
+file_format: "0.3"
+resource:
+  attributes:
+    - name: service.name
+      value: declarative-service
+    - name: service.namespace
+      value: checkout
+    - name: team
+      value: payments
+    - name: replicas
+      value: 3
+      type: int
+  attributes_list: "team=ignored,tier=backend,owner=platform%20team"
+propagator:
+  composite: [tracecontext, baggage]
+tracer_provider:
+  processors:
+    - batch:
+        schedule_delay: 1000
+        max_queue_size: 4096
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: http://localhost:4318/v1/traces
+            compression: none
+            timeout: 5000
+            headers:
+              - name: api-key
+                value: ${TEST_OTLP_API_KEY}
+            headers_list: "x-tenant=acme,api-key=ignored,Authorization=Basic%20abc%3D"
+  sampler:
+    parent_based:
+      root:
+        trace_id_ratio_based:
+          ratio: 0.25
+      remote_parent_not_sampled:
+        always_off:
+meter_provider:
+  readers:
+    - pull:
+        exporter:
+          prometheus:
+            host: 0.0.0.0
+            port: 9464
+            without_scope_info: true
+            translation_strategy: NoTranslation
+logger_provider:
+  processors:
+    - batch:
+        export_timeout: 2000
+        exporter:
+          console: {}
+`)
+
+		config, err := LoadDeclarativeConfig(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.ServiceName != "declarative-service" {
+			t.Errorf("expected service name declarative-service, got %s", config.ServiceName)
+		}
+
+		if config.ServiceNamespace != "checkout" {
+			t.Errorf("expected service namespace checkout, got %s", config.ServiceNamespace)
+		}
+
+		expectedAttributes := map[string]string{
+			"team":     "payments",
+			"tier":     "backend",
+			"owner":    "platform team",
+			"replicas": "3",
+		}
+		if !maps.Equal(config.ResourceAttributes, expectedAttributes) {
+			t.Errorf("expected resource attributes %v, got %v", expectedAttributes, config.ResourceAttributes)
+		}
+
+		expectedPropagators := []OTELPropagatorType{OTELPropagatorTraceContext, OTELPropagatorBaggage}
+		if !slices.Equal(config.Propagators, expectedPropagators) {
+			t.Errorf("expected propagators %v, got %v", expectedPropagators, config.Propagators)
+		}
+
+		if config.TracesExporter != OTELTracesExporterOTLP {
+			t.Errorf("expected traces exporter otlp, got %s", config.TracesExporter)
+		}
+
+		if config.OtlpTracesEndpoint != "http://localhost:4318/v1/traces" {
+			t.Errorf("unexpected traces endpoint: %s", config.OtlpTracesEndpoint)
+		}
+
+		if config.GetOTLPTracesProtocol() != OTLPProtocolHTTPProtobuf {
+			t.Errorf("expected traces protocol http/protobuf, got %s", config.GetOTLPTracesProtocol())
+		}
+
+		if config.GetOTLPTracesCompression() != OTLPCompressionNone {
+			t.Errorf("expected traces compression none, got %s", config.GetOTLPTracesCompression())
+		}
+
+		if config.OtlpTracesTimeout == nil || *config.OtlpTracesTimeout != 5000 {
+			t.Errorf("expected traces timeout 5000, got %v", config.OtlpTracesTimeout)
+		}
+
+		headers := config.GetOTLPTracesHeaders()
+		if headers["api-key"] != "secret" || headers["x-tenant"] != "acme" || headers["Authorization"] != "Basic abc=" {
+			t.Errorf("unexpected traces headers: %v", headers)
+		}
+
+		if config.TracesScheduleDelay == nil || *config.TracesScheduleDelay != 1000 {
+			t.Errorf("expected traces schedule delay 1000, got %v", config.TracesScheduleDelay)
+		}
+
+		if config.TracesMaxQueueSize == nil || *config.TracesMaxQueueSize != 4096 {
+			t.Errorf("expected traces max queue size 4096, got %v", config.TracesMaxQueueSize)
+		}
+
+		if config.TracesSampler != OTELTracesSamplerParentBasedTraceIDRatio {
+			t.Errorf("expected traces sampler parentbased_traceidratio, got %s", config.TracesSampler)
+		}
+
+		if config.GetTracesSamplerArg() != 0.25 {
+			t.Errorf("expected traces sampler arg 0.25, got %f", config.GetTracesSamplerArg())
+		}
+
+		if config.MetricsExporter != OTELMetricsExporterPrometheus {
+			t.Errorf("expected metrics exporter prometheus, got %s", config.MetricsExporter)
+		}
+
+		if config.PrometheusPort == nil || *config.PrometheusPort != 9464 {
+			t.Errorf("expected prometheus port 9464, got %v", config.PrometheusPort)
+		}
+
+		if config.PrometheusWithoutScopeInfo == nil || !*config.PrometheusWithoutScopeInfo ||
+			config.PrometheusTranslationStrategy != PrometheusTranslationNoTranslation {
+			t.Errorf(
+				"unexpected Prometheus naming options: %v, %s",
+				config.PrometheusWithoutScopeInfo,
+				config.PrometheusTranslationStrategy,
+			)
+		}
+
+		if config.LogsExporter != OTELLogsExporterConsole {
+			t.Errorf("expected logs exporter console, got %s", config.LogsExporter)
+		}
+
+		if config.LogsExportTimeout == nil || *config.LogsExportTimeout != 2000 {
+			t.Errorf("expected logs export timeout 2000, got %v", config.LogsExportTimeout)
+		}
+	})
+
+	t.Run("loads file format 1.x", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.yaml", `
+file_format: "1.0"
+resource:
+  detection/development:
+    detectors:
+      - container:
+      - host:
+propagator:
+  composite:
+    - tracecontext:
+    - b3multi:
+  composite_list: "tracecontext,jaeger"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp_http:
+            endpoint: https://collector:4318/v1/traces
+            tls:
+              ca_file: /certs/ca.pem
+              cert_file: /certs/client.pem
+              key_file: /certs/client-key.pem
+  sampler:
+    always_off:
+meter_provider:
+  exemplar_filter: always_on
+  readers:
+    - periodic:
+        interval: 1000
+        timeout: 500
+        cardinality_limits:
+          default: 500
+        exporter:
+          otlp_grpc:
+            endpoint: http://collector:4317
+            insecure: true
+            temporality_preference: delta
+            default_histogram_aggregation: base2_exponential_bucket_histogram
+  views:
+    - selector:
+        instrument_name: http.server.request.duration
+      stream:
+        name: http.server.duration
+        aggregation:
+          explicit_bucket_histogram:
+            boundaries: [0.1, 0.5, 1]
+        attribute_keys:
+          included:
+            - http.route
+    - selector:
+        instrument_name: "rpc.*"
+        meter_name: grpc
+      stream:
+        aggregation:
+          drop:
+    - selector:
+        instrument_name: db.client.operation.duration
+      stream:
+        aggregation:
+          explicit_bucket_histogram:
+logger_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp_file/development:
+            output_stream: file:///var/log/otel/logs.jsonl
+`)
+
+		config, err := LoadDeclarativeConfig(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedPropagators := []OTELPropagatorType{
+			OTELPropagatorTraceContext,
+			OTELPropagatorB3Multi,
+			OTELPropagatorJaeger,
+		}
+		if !slices.Equal(config.Propagators, expectedPropagators) {
+			t.Errorf("expected propagators %v, got %v", expectedPropagators, config.Propagators)
+		}
+
+		expectedDetectors := []OTELResourceDetectorType{OTELResourceDetectorContainer, OTELResourceDetectorHost}
+		if !slices.Equal(config.ResourceDetectors, expectedDetectors) {
+			t.Errorf("expected resource detectors %v, got %v", expectedDetectors, config.ResourceDetectors)
+		}
+
+		if config.OtlpTracesProtocol != OTLPProtocolHTTPProtobuf {
+			t.Errorf("expected traces protocol http/protobuf, got %s", config.OtlpTracesProtocol)
+		}
+
+		if config.OtlpTracesCertificate != "/certs/ca.pem" ||
+			config.OtlpTracesClientCertificate != "/certs/client.pem" ||
+			config.OtlpTracesClientKey != "/certs/client-key.pem" {
+			t.Errorf(
+				"unexpected traces TLS files: %s, %s, %s",
+				config.OtlpTracesCertificate,
+				config.OtlpTracesClientCertificate,
+				config.OtlpTracesClientKey,
+			)
+		}
+
+		if config.TracesSampler != OTELTracesSamplerAlwaysOff {
+			t.Errorf("expected traces sampler always_off, got %s", config.TracesSampler)
+		}
+
+		expectedViews := []MetricViewConfig{
+			{
+				InstrumentName: "http.server.request.duration",
+				Name:           "http.server.duration",
+				Aggregation:    OTELMetricAggregationExplicitBucketHistogram,
+				Buckets:        []float64{0.1, 0.5, 1},
+				AttributeKeys:  []string{"http.route"},
+			},
+			{
+				InstrumentName: "rpc.*",
+				MeterName:      "grpc",
+				Aggregation:    OTELMetricAggregationDrop,
+			},
+			{
+				InstrumentName: "db.client.operation.duration",
+				Aggregation:    OTELMetricAggregationExplicitBucketHistogram,
+				Buckets:        defaultHistogramBuckets,
+			},
+		}
+		if !reflect.DeepEqual(config.MetricViews, expectedViews) {
+			t.Errorf("expected metric views %+v, got %+v", expectedViews, config.MetricViews)
+		}
+
+		if config.MetricsExporter != OTELMetricsExporterOTLP {
+			t.Errorf("expected metrics exporter otlp, got %s", config.MetricsExporter)
+		}
+
+		if config.MetricsExemplarFilter != OTELMetricsExemplarFilterAlwaysOn {
+			t.Errorf("expected exemplar filter always_on, got %s", config.MetricsExemplarFilter)
+		}
+
+		if config.MetricsCardinalityLimit == nil || *config.MetricsCardinalityLimit != 500 {
+			t.Errorf("expected metrics cardinality limit 500, got %v", config.MetricsCardinalityLimit)
+		}
+
+		if config.OtlpMetricsTemporalityPreference != OTLPMetricsTemporalityDelta ||
+			config.OtlpMetricsDefaultHistogramAggregation != OTELMetricAggregationBase2ExponentialBucketHistogram {
+			t.Errorf(
+				"unexpected metrics temporality and histogram aggregation: %s, %s",
+				config.OtlpMetricsTemporalityPreference,
+				config.OtlpMetricsDefaultHistogramAggregation,
+			)
+		}
+
+		if config.OtlpMetricsProtocol != OTLPProtocolGRPC {
+			t.Errorf("expected metrics protocol grpc, got %s", config.OtlpMetricsProtocol)
+		}
+
+		if config.OtlpMetricsInsecure == nil || !*config.OtlpMetricsInsecure {
+			t.Errorf("expected metrics insecure to be true, got %v", config.OtlpMetricsInsecure)
+		}
+
+		if config.MetricsExportInterval == nil || *config.MetricsExportInterval != 1000 {
+			t.Errorf("expected metrics export interval 1000, got %v", config.MetricsExportInterval)
+		}
+
+		if config.LogsExporter != OTELLogsExporterFile {
+			t.Errorf("expected logs exporter file, got %s", config.LogsExporter)
+		}
+
+		if config.LogsFilePath != "/var/log/otel/logs.jsonl" {
+			t.Errorf("expected logs file path /var/log/otel/logs.jsonl, got %s", config.LogsFilePath)
+		}
+	})
+
+	t.Run("disables signals and propagation without providers", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.yaml", `file_format: "0.3"`)
+
+		config, err := LoadDeclarativeConfig(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedPropagators := []OTELPropagatorType{OTELPropagatorNone}
+		if !slices.Equal(config.Propagators, expectedPropagators) {
+			t.Errorf("expected propagators %v, got %v", expectedPropagators, config.Propagators)
+		}
+
+		if config.TracesExporter != OTELTracesExporterNone ||
+			config.MetricsExporter != OTELMetricsExporterNone ||
+			config.LogsExporter != OTELLogsExporterNone {
+			t.Errorf(
+				"expected all exporters to be none, got %s, %s, %s",
+				config.TracesExporter,
+				config.MetricsExporter,
+				config.LogsExporter,
+			)
+		}
+	})
+
+	t.Run("disables the SDK", func(t *testing.T) {
+		filePath := writeTestConfigFile(t, "otel.yaml", `
+file_format: "0.3"
+disabled: true
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          console:
+`)
+
+		config, err := LoadDeclarativeConfig(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.TracesExporter != OTELTracesExporterNone {
+			t.Errorf("expected traces exporter none, got %s", config.TracesExporter)
+		}
+
+		if config.SdkDisabled == nil || !*config.SdkDisabled {
+			t.Errorf("expected the SDK to be disabled, got %v", config.SdkDisabled)
+		}
+	})
+
+	testErrorCases := []struct {
+		Name          string
+		Content       string
+		ExpectedError error
+	}{
+		{
+			Name:          "unsupported file format",
+			Content:       `file_format: "2.0"`,
+			ExpectedError: ErrUnsupportedDeclarativeFileFormat,
+		},
+		{
+			Name: "multiple processors",
+			Content: `
+file_format: "0.3"
+tracer_provider:
+  processors:
+    - batch: {exporter: {console: {}}}
+    - batch: {exporter: {console: {}}}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "simple processor",
+			Content: `
+file_format: "0.3"
+logger_provider:
+  processors:
+    - simple: {exporter: {console: {}}}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported exporter",
+			Content: `
+file_format: "0.3"
+tracer_provider:
+  processors:
+    - batch: {exporter: {zipkin: {endpoint: "http://localhost:9411"}}}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported sampler",
+			Content: `
+file_format: "0.3"
+tracer_provider:
+  sampler:
+    jaeger_remote: {}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported view selector",
+			Content: `
+file_format: "0.3"
+meter_provider:
+  views:
+    - selector: {instrument_type: histogram}
+      stream: {aggregation: {drop: {}}}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "cardinality limit per instrument kind",
+			Content: `
+file_format: "0.3"
+meter_provider:
+  readers:
+    - pull:
+        exporter: {prometheus: {}}
+        cardinality_limits: {counter: 100}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported top-level field",
+			Content: `
+file_format: "0.3"
+attribute_limits:
+  attribute_count_limit: 128
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported exporter field",
+			Content: `
+file_format: "1.0"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp_http:
+            endpoint: http://localhost:4318/v1/traces
+            encoding: json
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported aggregation option",
+			Content: `
+file_format: "1.0"
+meter_provider:
+  views:
+    - selector: {instrument_name: http.server.request.duration}
+      stream:
+        aggregation:
+          base2_exponential_bucket_histogram: {max_scale: 10}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported parent_based delegate sampler",
+			Content: `
+file_format: "0.3"
+tracer_provider:
+  sampler:
+    parent_based:
+      root: {always_on: {}}
+      remote_parent_not_sampled: {always_on: {}}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "unsupported prometheus host",
+			Content: `
+file_format: "0.3"
+meter_provider:
+  readers:
+    - pull:
+        exporter: {prometheus: {host: localhost, port: 9464}}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "array resource attribute",
+			Content: `
+file_format: "0.3"
+resource:
+  attributes:
+    - name: regions
+      value: [us-east-1, eu-west-1]
+      type: string_array
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "non-scalar header value",
+			Content: `
+file_format: "0.3"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            endpoint: http://localhost:4318
+            headers:
+              - name: api-key
+                value: {secret: abc}
+`,
+			ExpectedError: ErrUnsupportedDeclarativeConfig,
+		},
+		{
+			Name: "invalid propagator",
+			Content: `
+file_format: "0.3"
+propagator:
+  composite: [xray]
+`,
+			ExpectedError: ErrInvalidOTELPropagatorType,
+		},
+	}
+
+	t.Run("returns errors of malformed key-value lists", func(t *testing.T) {
+		for _, content := range []string{
+			`
+file_format: "0.3"
+resource:
+  attributes_list: "tier=backend,owner"
+`,
+			`
+file_format: "0.3"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            endpoint: http://localhost:4318
+            headers_list: "Authorization=Basic%2"
+`,
+		} {
+			_, err := LoadDeclarativeConfig(writeTestConfigFile(t, "otel.yaml", content))
+			if err == nil || !strings.Contains(err.Error(), "list") {
+				t.Errorf("expected error of the malformed list, got: %v", err)
+			}
+		}
+	})
+
+	for _, tc := range testErrorCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filePath := writeTestConfigFile(t, "otel.yaml", tc.Content)
+
+			_, err := LoadDeclarativeConfig(filePath)
+			if !errors.Is(err, tc.ExpectedError) {
+				t.Errorf("expected error %v, got: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestSubstituteDeclarativeEnv(t *testing.T) {
+	t.Setenv("TEST_SERVICE_NAME", "my-service")
+	t.Setenv("TEST_EMPTY", "")
+
+	input := `a: ${TEST_SERVICE_NAME}, b: ${env:TEST_SERVICE_NAME}, c: ${TEST_MISSING:-fallback}, d: ${TEST_EMPTY:-empty}, e: ${TEST_MISSING}, f: $${TEST_SERVICE_NAME}`
+	expected := `a: my-service, b: my-service, c: fallback, d: empty, e: , f: ${TEST_SERVICE_NAME}`
+
+	result := string(substituteDeclarativeEnv([]byte(input)))
+	if result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestLoadOTLPConfig_Declarative(t *testing.T) {
+	content := `
+file_format: "0.3"
+resource:
+  attributes:
+    - name: service.name
+      value: declarative-service
+`
+
+	t.Run("detects the file format field", func(t *testing.T) {
+		t.Setenv("OTEL_SERVICE_NAME", "env-service")
+
+		config, err := LoadOTLPConfig(writeTestConfigFile(t, "otel.yaml", content))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.ServiceName != "declarative-service" {
+			t.Errorf("expected service name declarative-service, got %s", config.ServiceName)
+		}
+	})
+
+	t.Run("loads the OTEL_CONFIG_FILE environment variable", func(t *testing.T) {
+		t.Setenv("OTEL_CONFIG_FILE", writeTestConfigFile(t, "otel.yaml", content))
+
+		config, err := LoadOTLPConfig("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if config.ServiceName != "declarative-service" {
+			t.Errorf("expected service name declarative-service, got %s", config.ServiceName)
+		}
+	})
+}
+
+func TestSetupOTelExporters_DeclarativeConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEST_TRACES_FILE", filepath.Join(dir, "traces.jsonl"))
+
+	filePath := writeTestConfigFile(t, "otel.yaml", `
+file_format: "1.0"
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp_file/development:
+            output_stream: file://${TEST_TRACES_FILE}
+`)
+
+	config, err := LoadDeclarativeConfig(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	_, span := exporters.Tracer.Start(context.Background(), "declarative-span")
+	span.End()
+
+	if err := exporters.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shutdown exporters: %v", err)
+	}
+
+	lines := readJSONLines(t, filepath.Join(dir, "traces.jsonl"))
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+
+	exportedSpan := getFirstJSONItem(t, lines[0], "resourceSpans", "scopeSpans", "spans")
+	if exportedSpan["name"] != "declarative-span" {
+		t.Errorf("expected span name 'declarative-span', got %v", exportedSpan["name"])
+	}
+}
@@ -8,13 +8,11 @@ import (
 	"net/http"
 	"os"
 
-	"github.com/caarlos0/env/v11"
 	"github.com/hasura/gotel"
 	"github.com/hasura/gotel/otelutils"
 )
 
 func main() {
-	os.Setenv("OTEL_METRIC_EXPORT_INTERVAL", "1000")
 	os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
 	os.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
 	os.Setenv("OTEL_METRICS_EXPORTER", "otlp")
@@ -25,14 +23,15 @@ func main() {
 		log.Fatalf("failed to initialize logger: %s", err)
 	}
 
-	otlpConfig, err := env.ParseAsWithOptions[gotel.OTLPConfig](env.Options{
-		DefaultValueTagName: "default",
-	})
+	otlpConfig, err := gotel.LoadOTLPConfig("")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	ts, err := gotel.SetupOTelExporters(context.TODO(), &otlpConfig, "v0.1.0", logger)
+	metricsExportInterval := uint(1000)
+	otlpConfig.MetricsExportInterval = &metricsExportInterval
+
+	ts, err := gotel.SetupOTelExporters(context.TODO(), otlpConfig, "v0.1.0", logger)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,219 @@
+package gotel
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLP JSON encodes trace and span IDs as hex strings instead of base64.
+var otlpJSONHexIDFields = map[string]bool{
+	"traceId":      true,
+	"spanId":       true,
+	"parentSpanId": true,
+}
+
+// fileExportTransport is an [http.RoundTripper] that appends OTLP export requests
+// to a file in the OTLP JSON lines format instead of sending them over the network.
+// It lets the file exporters reuse the serialization of the OTLP HTTP exporters.
+type fileExportTransport struct {
+	path       string
+	newMessage func() proto.Message
+	mu         sync.Mutex
+}
+
+func newFileExportTransport(path string, newMessage func() proto.Message) *fileExportTransport {
+	return &fileExportTransport{
+		path:       path,
+		newMessage: newMessage,
+	}
+}
+
+// RoundTrip writes the OTLP request body to the file and responds with an empty success response.
+func (fet *fileExportTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	defer req.Body.Close()
+
+	var reader io.Reader = req.Body
+
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		defer gzipReader.Close()
+
+		reader = gzipReader
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := fet.encodeJSONLine(body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fet.appendLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     http.StatusText(http.StatusOK),
+		Proto:      req.Proto,
+		ProtoMajor: req.ProtoMajor,
+		ProtoMinor: req.ProtoMinor,
+		Header: http.Header{
+			contentTypeHeader: []string{"application/x-protobuf"},
+		},
+		Body:    http.NoBody,
+		Request: req,
+	}, nil
+}
+
+func (fet *fileExportTransport) encodeJSONLine(body []byte) ([]byte, error) {
+	message := fet.newMessage()
+
+	err := proto.Unmarshal(body, message)
+	if err != nil {
+		return nil, err
+	}
+
+	rawJSON, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode the JSON again to convert trace and span IDs to hex strings.
+	decoder := json.NewDecoder(bytes.NewReader(rawJSON))
+	decoder.UseNumber()
+
+	var value any
+
+	err = decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	encodeOTLPJSONHexIDs(value)
+
+	buffer := new(bytes.Buffer)
+	enc := json.NewEncoder(buffer)
+	enc.SetEscapeHTML(false)
+
+	err = enc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (fet *fileExportTransport) appendLine(line []byte) error {
+	fet.mu.Lock()
+	defer fet.mu.Unlock()
+
+	file, err := os.OpenFile(fet.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(line)
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+func encodeOTLPJSONHexIDs(value any) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, item := range typedValue {
+			if !otlpJSONHexIDFields[key] {
+				encodeOTLPJSONHexIDs(item)
+
+				continue
+			}
+
+			str, ok := item.(string)
+			if !ok {
+				continue
+			}
+
+			rawID, err := base64.StdEncoding.DecodeString(str)
+			if err == nil {
+				typedValue[key] = hex.EncodeToString(rawID)
+			}
+		}
+	case []any:
+		for _, item := range typedValue {
+			encodeOTLPJSONHexIDs(item)
+		}
+	}
+}
+
+func newFileTraceExporter(ctx context.Context, path string) (*otlptrace.Exporter, error) {
+	transport := newFileExportTransport(path, func() proto.Message {
+		return &coltracepb.ExportTraceServiceRequest{}
+	})
+
+	return otlptracehttp.New(
+		ctx,
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithCompression(otlptracehttp.NoCompression),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+		otlptracehttp.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+}
+
+func newFileMetricExporter(ctx context.Context, path string) (*otlpmetrichttp.Exporter, error) {
+	transport := newFileExportTransport(path, func() proto.Message {
+		return &colmetricspb.ExportMetricsServiceRequest{}
+	})
+
+	return otlpmetrichttp.New(
+		ctx,
+		otlpmetrichttp.WithInsecure(),
+		otlpmetrichttp.WithCompression(otlpmetrichttp.NoCompression),
+		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{Enabled: false}),
+		otlpmetrichttp.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+}
+
+func newFileLogExporter(ctx context.Context, path string) (*otlploghttp.Exporter, error) {
+	transport := newFileExportTransport(path, func() proto.Message {
+		return &collogspb.ExportLogsServiceRequest{}
+	})
+
+	return otlploghttp.New(
+		ctx,
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithCompression(otlploghttp.NoCompression),
+		otlploghttp.WithRetry(otlploghttp.RetryConfig{Enabled: false}),
+		otlploghttp.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+}
@@ -0,0 +1,270 @@
+package gotel
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var hexTraceIDRegex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// Helper function to read JSON lines from a file
+func readJSONLines(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var results []map[string]any
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("failed to decode JSON line %s: %v", scanner.Text(), err)
+		}
+
+		results = append(results, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return results
+}
+
+// Helper function to walk into nested JSON arrays and objects
+func getFirstJSONItem(t *testing.T, value any, keys ...string) map[string]any {
+	t.Helper()
+
+	current, ok := value.(map[string]any)
+	if !ok {
+		t.Fatalf("expected JSON object, got %v", value)
+	}
+
+	for _, key := range keys {
+		items, ok := current[key].([]any)
+		if !ok || len(items) == 0 {
+			t.Fatalf("expected non-empty array at %s, got %v", key, current[key])
+		}
+
+		current, ok = items[0].(map[string]any)
+		if !ok {
+			t.Fatalf("expected JSON object in %s, got %v", key, items[0])
+		}
+	}
+
+	return current
+}
+
+func TestSetupOTelExporters_FileExporters(t *testing.T) {
+	dir := t.TempDir()
+	config := &OTLPConfig{
+		ServiceName:     "file-exporter-test",
+		TracesExporter:  OTELTracesExporterFile,
+		MetricsExporter: OTELMetricsExporterFile,
+		LogsExporter:    OTELLogsExporterFile,
+		TracesFilePath:  filepath.Join(dir, "traces.jsonl"),
+		MetricsFilePath: filepath.Join(dir, "metrics.jsonl"),
+		LogsFilePath:    filepath.Join(dir, "logs.jsonl"),
+		// export the test metric only.
+		DisableGoMetrics: boolPtr(true),
+	}
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	ctx, span := exporters.Tracer.Start(context.Background(), "file-span")
+
+	counter, err := exporters.Meter.Int64Counter("file.exporter.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	counter.Add(ctx, 3)
+	exporters.Logger.InfoContext(ctx, "hello file exporter")
+	span.End()
+
+	if err := exporters.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shutdown exporters: %v", err)
+	}
+
+	t.Run("writes traces in OTLP JSON", func(t *testing.T) {
+		lines := readJSONLines(t, config.TracesFilePath)
+		if len(lines) != 1 {
+			t.Fatalf("expected 1 line, got %d", len(lines))
+		}
+
+		span := getFirstJSONItem(t, lines[0], "resourceSpans", "scopeSpans", "spans")
+		if span["name"] != "file-span" {
+			t.Errorf("expected span name 'file-span', got %v", span["name"])
+		}
+
+		traceID, _ := span["traceId"].(string)
+		if !hexTraceIDRegex.MatchString(traceID) {
+			t.Errorf("expected hex encoded trace ID, got %v", span["traceId"])
+		}
+
+		if _, ok := span["kind"].(float64); !ok {
+			t.Errorf("expected numeric span kind, got %v", span["kind"])
+		}
+	})
+
+	t.Run("writes metrics in OTLP JSON", func(t *testing.T) {
+		lines := readJSONLines(t, config.MetricsFilePath)
+		if len(lines) == 0 {
+			t.Fatal("expected at least 1 line")
+		}
+
+		metric := getFirstJSONItem(t, lines[0], "resourceMetrics", "scopeMetrics", "metrics")
+		if metric["name"] != "file.exporter.requests" {
+			t.Errorf("expected metric name 'file.exporter.requests', got %v", metric["name"])
+		}
+	})
+
+	t.Run("writes logs in OTLP JSON", func(t *testing.T) {
+		lines := readJSONLines(t, config.LogsFilePath)
+		if len(lines) != 1 {
+			t.Fatalf("expected 1 line, got %d", len(lines))
+		}
+
+		record := getFirstJSONItem(t, lines[0], "resourceLogs", "scopeLogs", "logRecords")
+
+		body, _ := record["body"].(map[string]any)
+		if body["stringValue"] != "hello file exporter" {
+			t.Errorf("expected log body 'hello file exporter', got %v", record["body"])
+		}
+
+		traceID, _ := record["traceId"].(string)
+		if !hexTraceIDRegex.MatchString(traceID) {
+			t.Errorf("expected hex encoded trace ID, got %v", record["traceId"])
+		}
+	})
+}
+
+// Helper function to wait until a file has at least one line
+func waitForFileLines(t *testing.T, path string) {
+	t.Helper()
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+
+	for time.Now().Before(deadline) {
+		content, err := os.ReadFile(path)
+		if err == nil && len(content) > 0 {
+			return
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %s to be written", path)
+}
+
+func TestSetupOTelExporters_ExportSchedule(t *testing.T) {
+	dir := t.TempDir()
+	config := &OTLPConfig{
+		ServiceName:           "export-schedule-test",
+		TracesExporter:        OTELTracesExporterFile,
+		MetricsExporter:       OTELMetricsExporterFile,
+		LogsExporter:          OTELLogsExporterFile,
+		TracesFilePath:        filepath.Join(dir, "traces.jsonl"),
+		MetricsFilePath:       filepath.Join(dir, "metrics.jsonl"),
+		LogsFilePath:          filepath.Join(dir, "logs.jsonl"),
+		TracesScheduleDelay:   uintPtr(50),
+		LogsScheduleDelay:     uintPtr(50),
+		MetricsExportInterval: uintPtr(50),
+	}
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+	defer exporters.Shutdown(context.Background())
+
+	ctx, span := exporters.Tracer.Start(context.Background(), "scheduled-span")
+
+	counter, err := exporters.Meter.Int64Counter("export.schedule.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	counter.Add(ctx, 1)
+	exporters.Logger.InfoContext(ctx, "hello export schedule")
+	span.End()
+
+	// the default schedule delays are multiple seconds,
+	// so the files are only written before shutdown if the configured delays are applied.
+	waitForFileLines(t, config.TracesFilePath)
+	waitForFileLines(t, config.MetricsFilePath)
+	waitForFileLines(t, config.LogsFilePath)
+}
+
+func TestSetupOTelExporters_ConsoleExporters(t *testing.T) {
+	config := &OTLPConfig{
+		ServiceName:     "console-exporter-test",
+		TracesExporter:  OTELTracesExporterConsole,
+		MetricsExporter: OTELMetricsExporterConsole,
+		LogsExporter:    OTELLogsExporterConsole,
+	}
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	if err := exporters.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shutdown exporters: %v", err)
+	}
+}
+
+func TestSetupOTelExporters_InvalidExporterTypes(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	testCases := []struct {
+		Name   string
+		Config OTLPConfig
+	}{
+		{
+			Name:   "invalid traces exporter",
+			Config: OTLPConfig{TracesExporter: "invalid"},
+		},
+		{
+			Name:   "invalid metrics exporter",
+			Config: OTLPConfig{MetricsExporter: "invalid"},
+		},
+		{
+			Name:   "invalid logs exporter",
+			Config: OTLPConfig{LogsExporter: "invalid"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := SetupOTelExporters(context.Background(), &tc.Config, "v1.0.0", logger)
+			if err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package gotel
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/semconv/v1.41.0/processconv"
+	"go.opentelemetry.io/otel/semconv/v1.41.0/systemconv"
+)
+
+const (
+	defaultProcfsPath = "/proc"
+	// clock ticks per second of CPU times in /proc/[pid]/stat (USER_HZ).
+	// It is 100 on all common Linux architectures.
+	procClockTicksPerSecond = 100
+	procKilobyte            = 1024
+)
+
+var errInvalidProcStat = errors.New("invalid proc stat")
+
+// procStats contains process statistics that are read from procfs.
+// Fields are nil if the source file is not readable, e.g. /proc/self/io requires the ptrace capability.
+type procStats struct {
+	UserCPUSeconds      *float64
+	SystemCPUSeconds    *float64
+	MemoryRSSBytes      *int64
+	MemoryVirtualBytes  *int64
+	ThreadCount         *int64
+	FileDescriptorCount *int64
+	DiskReadBytes       *int64
+	DiskWriteBytes      *int64
+	// network interfaces of /proc/self/net/dev. The counters belong to the network namespace
+	// of the process, so they include the traffic of other processes in the same namespace.
+	NetworkInterfaces []procNetworkInterface
+}
+
+// procNetworkInterface contains the traffic counters of a network interface in /proc/[pid]/net/dev.
+type procNetworkInterface struct {
+	Name          string
+	ReceiveBytes  int64
+	TransmitBytes int64
+}
+
+// start observing process metrics from procfs if host metrics are enabled on Linux.
+func setupHostMetrics(config *OTLPConfig, meterProvider metricapi.MeterProvider) error {
+	if config.EnableHostMetrics == nil || !*config.EnableHostMetrics || runtime.GOOS != "linux" {
+		return nil
+	}
+
+	return startHostMetrics(meterProvider.Meter(instrumentationScopeName), config.GetHostMetricsProcfsPath())
+}
+
+// register observable instruments of process metrics with semantic convention names
+// that are read from the procfs directory on every collection.
+func startHostMetrics(meter metricapi.Meter, procfsPath string) error {
+	cpuTime, err := processconv.NewCPUTime(meter)
+	if err != nil {
+		return err
+	}
+
+	memoryUsage, err := processconv.NewMemoryUsageObservable(meter)
+	if err != nil {
+		return err
+	}
+
+	memoryVirtual, err := processconv.NewMemoryVirtualObservable(meter)
+	if err != nil {
+		return err
+	}
+
+	threadCount, err := processconv.NewThreadCountObservable(meter)
+	if err != nil {
+		return err
+	}
+
+	fdCount, err := processconv.NewUnixFileDescriptorCountObservable(meter)
+	if err != nil {
+		return err
+	}
+
+	diskIO, err := processconv.NewDiskIOObservable(meter)
+	if err != nil {
+		return err
+	}
+
+	// the network I/O is namespace-wide, so it uses the system name instead of process.network.io.
+	networkIO, err := systemconv.NewNetworkIO(meter)
+	if err != nil {
+		return err
+	}
+
+	userOpt := metricapi.WithAttributes(cpuTime.AttrCPUMode(processconv.CPUModeUser))
+	systemOpt := metricapi.WithAttributes(cpuTime.AttrCPUMode(processconv.CPUModeSystem))
+	diskReadOpt := metricapi.WithAttributes(diskIO.AttrDiskIODirection(processconv.DiskIODirectionRead))
+	diskWriteOpt := metricapi.WithAttributes(diskIO.AttrDiskIODirection(processconv.DiskIODirectionWrite))
+
+	_, err = meter.RegisterCallback(
+		func(_ context.Context, observer metricapi.Observer) error {
+			stats := readProcStats(procfsPath)
+
+			observeFloat64(observer, cpuTime.Inst(), stats.UserCPUSeconds, userOpt)
+			observeFloat64(observer, cpuTime.Inst(), stats.SystemCPUSeconds, systemOpt)
+			observeInt64(observer, memoryUsage.Inst(), stats.MemoryRSSBytes)
+			observeInt64(observer, memoryVirtual.Inst(), stats.MemoryVirtualBytes)
+			observeInt64(observer, threadCount.Inst(), stats.ThreadCount)
+			observeInt64(observer, fdCount.Inst(), stats.FileDescriptorCount)
+			observeInt64(observer, diskIO.Inst(), stats.DiskReadBytes, diskReadOpt)
+			observeInt64(observer, diskIO.Inst(), stats.DiskWriteBytes, diskWriteOpt)
+			observeNetworkIO(observer, networkIO, stats.NetworkInterfaces)
+
+			return nil
+		},
+		cpuTime.Inst(),
+		memoryUsage.Inst(),
+		memoryVirtual.Inst(),
+		threadCount.Inst(),
+		fdCount.Inst(),
+		diskIO.Inst(),
+		networkIO.Inst(),
+	)
+
+	return err
+}
+
+func observeInt64(
+	observer metricapi.Observer,
+	instrument metricapi.Int64Observable,
+	value *int64,
+	options ...metricapi.ObserveOption,
+) {
+	if value != nil {
+		observer.ObserveInt64(instrument, *value, options...)
+	}
+}
+
+func observeNetworkIO(
+	observer metricapi.Observer,
+	networkIO systemconv.NetworkIO,
+	interfaces []procNetworkInterface,
+) {
+	for _, networkInterface := range interfaces {
+		nameAttr := networkIO.AttrNetworkInterfaceName(networkInterface.Name)
+
+		observer.ObserveInt64(networkIO.Inst(), networkInterface.ReceiveBytes, metricapi.WithAttributes(
+			nameAttr, networkIO.AttrNetworkIODirection(systemconv.NetworkIODirectionReceive),
+		))
+		observer.ObserveInt64(networkIO.Inst(), networkInterface.TransmitBytes, metricapi.WithAttributes(
+			nameAttr, networkIO.AttrNetworkIODirection(systemconv.NetworkIODirectionTransmit),
+		))
+	}
+}
+
+func observeFloat64(
+	observer metricapi.Observer,
+	instrument metricapi.Float64Observable,
+	value *float64,
+	options ...metricapi.ObserveOption,
+) {
+	if value != nil {
+		observer.ObserveFloat64(instrument, *value, options...)
+	}
+}
+
+// read statistics of the current process from the procfs directory.
+// Unreadable files are skipped.
+func readProcStats(procfsPath string) procStats {
+	result := procStats{}
+	selfPath := filepath.Join(procfsPath, "self")
+
+	userCPU, systemCPU, err := readProcCPUTimes(filepath.Join(selfPath, "stat"))
+	if err == nil {
+		result.UserCPUSeconds = &userCPU
+		result.SystemCPUSeconds = &systemCPU
+	}
+
+	status := readProcKeyValues(filepath.Join(selfPath, "status"))
+	result.MemoryRSSBytes = parseProcKilobytes(status["VmRSS"])
+	result.MemoryVirtualBytes = parseProcKilobytes(status["VmSize"])
+	result.ThreadCount = parseProcInt64(status["Threads"])
+
+	fdEntries, err := os.ReadDir(filepath.Join(selfPath, "fd"))
+	if err == nil {
+		// the entries are counted as they are like the process collector of Prometheus,
+		// so the count of the live procfs includes the descriptor that is opened to read the directory.
+		fdCount := int64(len(fdEntries))
+		result.FileDescriptorCount = &fdCount
+	}
+
+	ioStats := readProcKeyValues(filepath.Join(selfPath, "io"))
+	result.DiskReadBytes = parseProcInt64(ioStats["read_bytes"])
+	result.DiskWriteBytes = parseProcInt64(ioStats["write_bytes"])
+	result.NetworkInterfaces = readProcNetworkInterfaces(filepath.Join(selfPath, "net", "dev"))
+
+	return result
+}
+
+// read received and transmitted bytes of network interfaces from /proc/[pid]/net/dev.
+// Returns nil if the file is not readable. Malformed lines are skipped.
+func readProcNetworkInterfaces(filePath string) []procNetworkInterface {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+
+	defer file.Close()
+
+	var result []procNetworkInterface
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		// the two header lines have no colon after the interface name.
+		name, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		// received bytes are the 1st field and transmitted bytes are the 9th field.
+		fields := strings.Fields(value)
+		if len(fields) < 9 {
+			continue
+		}
+
+		receiveBytes := parseProcInt64(fields[0])
+		transmitBytes := parseProcInt64(fields[8])
+
+		if receiveBytes == nil || transmitBytes == nil {
+			continue
+		}
+
+		result = append(result, procNetworkInterface{
+			Name:          strings.TrimSpace(name),
+			ReceiveBytes:  *receiveBytes,
+			TransmitBytes: *transmitBytes,
+		})
+	}
+
+	return result
+}
+
+// read user and system CPU times in seconds from /proc/[pid]/stat.
+func readProcCPUTimes(filePath string) (float64, float64, error) {
+	rawBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the command name in parentheses may contain spaces, so fields are counted after the last parenthesis.
+	content := string(rawBytes)
+
+	index := strings.LastIndexByte(content, ')')
+	if index < 0 {
+		return 0, 0, fmt.Errorf("%w: %s", errInvalidProcStat, filePath)
+	}
+
+	// fields start from the process state that is the 3rd field, so utime (14) and stime (15) are at 11 and 12.
+	fields := strings.Fields(content[index+1:])
+	if len(fields) < 13 {
+		return 0, 0, fmt.Errorf("%w: %s", errInvalidProcStat, filePath)
+	}
+
+	userTicks, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %w", errInvalidProcStat, err)
+	}
+
+	systemTicks, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %w", errInvalidProcStat, err)
+	}
+
+	return float64(userTicks) / procClockTicksPerSecond, float64(systemTicks) / procClockTicksPerSecond, nil
+}
+
+// read lines in the "key: value" format, e.g. /proc/[pid]/status and /proc/[pid]/io.
+// Returns an empty map if the file is not readable.
+func readProcKeyValues(filePath string) map[string]string {
+	result := map[string]string{}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return result
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok {
+			result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return result
+}
+
+// parse values in the "1234 kB" format to bytes.
+func parseProcKilobytes(value string) *int64 {
+	result := parseProcInt64(strings.TrimSuffix(value, " kB"))
+	if result != nil {
+		*result *= procKilobyte
+	}
+
+	return result
+}
+
+func parseProcInt64(value string) *int64 {
+	result, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &result
+}
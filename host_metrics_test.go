@@ -0,0 +1,220 @@
+package gotel
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestReadProcStats(t *testing.T) {
+	t.Run("reads stats from procfs", func(t *testing.T) {
+		stats := readProcStats(filepath.Join("testdata", "procfs"))
+
+		float64Values := map[string]*float64{
+			"UserCPUSeconds":   stats.UserCPUSeconds,
+			"SystemCPUSeconds": stats.SystemCPUSeconds,
+		}
+		expectedFloat64Values := map[string]float64{
+			"UserCPUSeconds":   2.5,
+			"SystemCPUSeconds": 0.75,
+		}
+
+		for key, expected := range expectedFloat64Values {
+			if float64Values[key] == nil || *float64Values[key] != expected {
+				t.Errorf("expected %s to be %v, got %v", key, expected, float64Values[key])
+			}
+		}
+
+		int64Values := map[string]*int64{
+			"MemoryRSSBytes":      stats.MemoryRSSBytes,
+			"MemoryVirtualBytes":  stats.MemoryVirtualBytes,
+			"ThreadCount":         stats.ThreadCount,
+			"FileDescriptorCount": stats.FileDescriptorCount,
+			"DiskReadBytes":       stats.DiskReadBytes,
+			"DiskWriteBytes":      stats.DiskWriteBytes,
+		}
+		expectedInt64Values := map[string]int64{
+			"MemoryRSSBytes":      12000 * 1024,
+			"MemoryVirtualBytes":  716800 * 1024,
+			"ThreadCount":         12,
+			"FileDescriptorCount": 3,
+			"DiskReadBytes":       4096,
+			"DiskWriteBytes":      8192,
+		}
+
+		for key, expected := range expectedInt64Values {
+			if int64Values[key] == nil || *int64Values[key] != expected {
+				t.Errorf("expected %s to be %d, got %v", key, expected, int64Values[key])
+			}
+		}
+
+		expectedInterfaces := []procNetworkInterface{
+			{Name: "lo", ReceiveBytes: 999999, TransmitBytes: 999999},
+			{Name: "eth0", ReceiveBytes: 1000000, TransmitBytes: 250000},
+			{Name: "eth1", ReceiveBytes: 24000, TransmitBytes: 6000},
+		}
+
+		if !reflect.DeepEqual(stats.NetworkInterfaces, expectedInterfaces) {
+			t.Errorf("expected network interfaces %+v, got %+v", expectedInterfaces, stats.NetworkInterfaces)
+		}
+	})
+
+	t.Run("skips unreadable files", func(t *testing.T) {
+		stats := readProcStats(filepath.Join("testdata", "not_found"))
+		if !reflect.DeepEqual(stats, procStats{}) {
+			t.Errorf("expected empty stats, got %+v", stats)
+		}
+	})
+}
+
+func TestStartHostMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	defer meterProvider.Shutdown(context.Background())
+
+	err := startHostMetrics(meterProvider.Meter(instrumentationScopeName), filepath.Join("testdata", "procfs"))
+	if err != nil {
+		t.Fatalf("failed to start host metrics: %v", err)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	if len(metrics.ScopeMetrics) != 1 {
+		t.Fatalf("expected 1 scope, got %d", len(metrics.ScopeMetrics))
+	}
+
+	dataPoints := map[string]map[string]float64{}
+
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		values := map[string]float64{}
+
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			for _, dp := range data.DataPoints {
+				values[getTestDataPointDirection(dp.Attributes)] = float64(dp.Value)
+			}
+		case metricdata.Sum[float64]:
+			for _, dp := range data.DataPoints {
+				values[getTestDataPointDirection(dp.Attributes)] = dp.Value
+			}
+		default:
+			t.Fatalf("unexpected data type of metric %s: %T", m.Name, m.Data)
+		}
+
+		dataPoints[m.Name] = values
+	}
+
+	expected := map[string]map[string]float64{
+		"process.cpu.time":                   {"user": 2.5, "system": 0.75},
+		"process.memory.usage":               {"": 12000 * 1024},
+		"process.memory.virtual":             {"": 716800 * 1024},
+		"process.thread.count":               {"": 12},
+		"process.unix.file_descriptor.count": {"": 3},
+		"process.disk.io":                    {"read": 4096, "write": 8192},
+		"system.network.io": {
+			"lo/receive":    999999,
+			"lo/transmit":   999999,
+			"eth0/receive":  1000000,
+			"eth0/transmit": 250000,
+			"eth1/receive":  24000,
+			"eth1/transmit": 6000,
+		},
+	}
+
+	for name, values := range expected {
+		for key, value := range values {
+			if dataPoints[name][key] != value {
+				t.Errorf("expected %s{%s} to be %v, got %v", name, key, value, dataPoints[name])
+			}
+		}
+	}
+}
+
+func TestSetupHostMetrics_Disabled(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	defer meterProvider.Shutdown(context.Background())
+
+	if err := setupHostMetrics(&OTLPConfig{}, meterProvider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	if len(metrics.ScopeMetrics) != 0 {
+		t.Errorf("expected no metrics, got %v", metrics.ScopeMetrics)
+	}
+}
+
+func TestSetupHostMetrics_ProcfsPath(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("host metrics are supported on Linux only")
+	}
+
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	defer meterProvider.Shutdown(context.Background())
+
+	config := &OTLPConfig{
+		EnableHostMetrics:     boolPtr(true),
+		HostMetricsProcfsPath: filepath.Join("testdata", "procfs"),
+	}
+
+	if err := setupHostMetrics(config, meterProvider); err != nil {
+		t.Fatalf("failed to setup host metrics: %v", err)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	for _, scopeMetrics := range metrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if m.Name != "process.thread.count" {
+				continue
+			}
+
+			data, ok := m.Data.(metricdata.Sum[int64])
+			if !ok || len(data.DataPoints) != 1 || data.DataPoints[0].Value != 12 {
+				t.Errorf("expected the thread count of the configured procfs, got %+v", m.Data)
+			}
+
+			return
+		}
+	}
+
+	t.Error("expected the process.thread.count metric")
+}
+
+// returns the value of the first mode or direction attribute of the data point,
+// prefixed with the network interface name if it exists, e.g. eth0/receive.
+func getTestDataPointDirection(attrs attribute.Set) string {
+	prefix := ""
+	if name, ok := attrs.Value("network.interface.name"); ok {
+		prefix = name.AsString() + "/"
+	}
+
+	for _, key := range []attribute.Key{"cpu.mode", "disk.io.direction", "network.io.direction"} {
+		if value, ok := attrs.Value(key); ok {
+			return prefix + value.AsString()
+		}
+	}
+
+	return prefix
+}
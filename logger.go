@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/hasura/gotel/otelutils"
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/credentials"
 )
 
 // LogHandler wraps slog logger with the OpenTelemetry logs exporter handler.
@@ -76,14 +80,68 @@ func newLoggerProvider(
 	config *OTLPConfig,
 	otelDisabled bool,
 	res *resource.Resource,
+	setupOpts *setupOptions,
 ) (*log.LoggerProvider, error) {
+	providerOptions := []log.LoggerProviderOption{
+		log.WithResource(res),
+	}
+
+	for _, processor := range setupOpts.logProcessors {
+		providerOptions = append(providerOptions, log.WithProcessor(processor))
+	}
+
+	// create the configured exporter first so that no batch processor is started if it fails.
+	logExporter, err := newLogExporter(ctx, config, otelDisabled)
+	if err != nil {
+		return nil, err
+	}
+
+	logExporters := setupOpts.logExporters
+	if logExporter != nil {
+		logExporters = append(slices.Clone(logExporters), logExporter)
+	}
+
+	for _, exporter := range logExporters {
+		providerOptions = append(
+			providerOptions,
+			log.WithProcessor(log.NewBatchProcessor(exporter, newBatchLogProcessorOptions(config)...)),
+		)
+	}
+
+	return log.NewLoggerProvider(providerOptions...), nil
+}
+
+// create the log exporter of the configured logs exporter.
+// Returns nil if the exporter is disabled or the OTLP logs endpoint is empty.
+func newLogExporter(ctx context.Context, config *OTLPConfig, otelDisabled bool) (log.Exporter, error) {
+	if otelDisabled {
+		return nil, nil
+	}
+
+	logsExporterType := config.GetLogsExporter()
+
+	switch logsExporterType {
+	case OTELLogsExporterConsole:
+		return stdoutlog.New(stdoutlog.WithPrettyPrint())
+	case OTELLogsExporterFile:
+		return newFileLogExporter(ctx, config.GetLogsFilePath())
+	case OTELLogsExporterOTLP:
+		return setupLogExporterOTLP(ctx, config)
+	case OTELLogsExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOTELLogsExporterType, logsExporterType)
+	}
+}
+
+func setupLogExporterOTLP(ctx context.Context, config *OTLPConfig) (log.Exporter, error) {
 	logsEndpoint := config.OtlpLogsEndpoint
 	if logsEndpoint == "" && config.OtlpEndpoint != "" {
 		logsEndpoint = config.OtlpEndpoint + "/v1/logs"
 	}
 
-	if otelDisabled || config.LogsExporter != OTELLogsExporterOTLP || logsEndpoint == "" {
-		return log.NewLoggerProvider(), nil
+	if logsEndpoint == "" {
+		return nil, nil
 	}
 
 	endpoint, protocol, insecure, err := parseOTLPEndpoint(
@@ -102,45 +160,68 @@ func newLoggerProvider(
 		return nil, fmt.Errorf("failed to parse OTLP logs compression: %w", err)
 	}
 
-	opts := []log.LoggerProviderOption{log.WithResource(res)}
+	tlsConfig, err := newOTLPTLSConfig(
+		getDefault(config.OtlpLogsCertificate, config.OtlpCertificate),
+		getDefault(config.OtlpLogsClientCertificate, config.OtlpClientCertificate),
+		getDefault(config.OtlpLogsClientKey, config.OtlpClientKey),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OTLP logs TLS certificates: %w", err)
+	}
 
 	if protocol == OTLPProtocolGRPC {
 		options := []otlploggrpc.Option{
 			otlploggrpc.WithEndpoint(endpoint),
 			otlploggrpc.WithCompressor(string(compressorStr)),
+			otlploggrpc.WithHeaders(config.GetOTLPLogsHeaders()),
+			otlploggrpc.WithTimeout(config.GetOTLPLogsTimeout()),
 		}
 
 		if insecure {
 			options = append(options, otlploggrpc.WithInsecure())
+		} else if tlsConfig != nil {
+			options = append(options, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
 		}
 
-		logExporter, err := otlploggrpc.New(ctx, options...)
-		if err != nil {
-			return nil, err
-		}
-
-		opts = append(opts, log.WithProcessor(log.NewBatchProcessor(logExporter)))
-
-		return log.NewLoggerProvider(opts...), nil
+		return otlploggrpc.New(ctx, options...)
 	}
 
 	options := []otlploghttp.Option{
 		otlploghttp.WithEndpointURL(endpoint),
 		otlploghttp.WithCompression(otlploghttp.Compression(compressorInt)),
+		otlploghttp.WithHeaders(config.GetOTLPLogsHeaders()),
+		otlploghttp.WithTimeout(config.GetOTLPLogsTimeout()),
 	}
 
 	if insecure {
 		options = append(options, otlploghttp.WithInsecure())
+	} else if tlsConfig != nil {
+		options = append(options, otlploghttp.WithTLSClientConfig(tlsConfig))
 	}
 
-	logExporter, err := otlploghttp.New(ctx, options...)
-	if err != nil {
-		return nil, err
+	return otlploghttp.New(ctx, options...)
+}
+
+func newBatchLogProcessorOptions(config *OTLPConfig) []log.BatchProcessorOption {
+	options := []log.BatchProcessorOption{}
+
+	if config.LogsMaxQueueSize != nil {
+		options = append(options, log.WithMaxQueueSize(int(*config.LogsMaxQueueSize)))
+	}
+
+	if config.LogsMaxExportBatchSize != nil {
+		options = append(options, log.WithExportMaxBatchSize(int(*config.LogsMaxExportBatchSize)))
+	}
+
+	if config.LogsExportTimeout != nil {
+		options = append(options, log.WithExportTimeout(time.Duration(*config.LogsExportTimeout)*time.Millisecond))
 	}
 
-	opts = append(opts, log.WithProcessor(log.NewBatchProcessor(logExporter)))
+	if config.LogsScheduleDelay != nil {
+		options = append(options, log.WithExportInterval(time.Duration(*config.LogsScheduleDelay)*time.Millisecond))
+	}
 
-	return log.NewLoggerProvider(opts...), nil
+	return options
 }
 
 // GetLogger gets the logger instance from context.
@@ -5,11 +5,15 @@ import (
 	"context"
 	"log/slog"
 	"net/http/httptest"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hasura/gotel/otelutils"
+	logapi "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -186,3 +190,165 @@ func TestGetRequestLogger(t *testing.T) {
 		}
 	})
 }
+
+// testLogExporter records exported batches of log records in place of an OTLP exporter.
+type testLogExporter struct {
+	// block exports until the channel is closed if set.
+	block chan struct{}
+
+	batches    []int
+	timeouts   []time.Duration
+	mu         sync.Mutex
+	exportedCh chan struct{}
+}
+
+func newTestLogExporter() *testLogExporter {
+	return &testLogExporter{
+		exportedCh: make(chan struct{}, 1),
+	}
+}
+
+func (te *testLogExporter) Export(ctx context.Context, records []log.Record) error {
+	if te.block != nil {
+		<-te.block
+	}
+
+	var timeout time.Duration
+
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	te.mu.Lock()
+	te.batches = append(te.batches, len(records))
+	te.timeouts = append(te.timeouts, timeout)
+	te.mu.Unlock()
+
+	select {
+	case te.exportedCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (te *testLogExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (te *testLogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (te *testLogExporter) getExported() ([]int, []time.Duration) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	return slices.Clone(te.batches), slices.Clone(te.timeouts)
+}
+
+func emitTestLogRecords(t *testing.T, processor *log.BatchProcessor, count int) {
+	t.Helper()
+
+	for i := range count {
+		var record log.Record
+		record.SetBody(logapi.IntValue(i))
+
+		if err := processor.OnEmit(context.Background(), &record); err != nil {
+			t.Fatalf("failed to emit log record: %v", err)
+		}
+	}
+}
+
+func TestNewBatchLogProcessorOptions(t *testing.T) {
+	t.Run("no options by default", func(t *testing.T) {
+		options := newBatchLogProcessorOptions(&OTLPConfig{})
+		if len(options) != 0 {
+			t.Errorf("expected no options, got %d", len(options))
+		}
+	})
+
+	t.Run("exports batches of the max batch size within the export timeout", func(t *testing.T) {
+		exporter := newTestLogExporter()
+		processor := log.NewBatchProcessor(exporter, newBatchLogProcessorOptions(&OTLPConfig{
+			LogsMaxQueueSize:       uintPtr(100),
+			LogsMaxExportBatchSize: uintPtr(2),
+			LogsExportTimeout:      uintPtr(1000),
+			LogsScheduleDelay:      uintPtr(3600000),
+		})...)
+		defer processor.Shutdown(context.Background())
+
+		emitTestLogRecords(t, processor, 5)
+
+		if err := processor.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("failed to flush processor: %v", err)
+		}
+
+		batches, timeouts := exporter.getExported()
+
+		var total int
+
+		for i, size := range batches {
+			total += size
+
+			if size > 2 {
+				t.Errorf("expected batches of at most 2 records, got %d", size)
+			}
+
+			if timeouts[i] <= 0 || timeouts[i] > time.Second {
+				t.Errorf("expected the export timeout of at most 1s, got %s", timeouts[i])
+			}
+		}
+
+		if total != 5 {
+			t.Errorf("expected 5 exported records, got %d", total)
+		}
+	})
+
+	t.Run("exports on the schedule delay", func(t *testing.T) {
+		exporter := newTestLogExporter()
+		processor := log.NewBatchProcessor(exporter, newBatchLogProcessorOptions(&OTLPConfig{
+			LogsMaxExportBatchSize: uintPtr(100),
+			LogsScheduleDelay:      uintPtr(10),
+		})...)
+		defer processor.Shutdown(context.Background())
+
+		emitTestLogRecords(t, processor, 1)
+
+		// the SDK default delay is 1s.
+		select {
+		case <-exporter.exportedCh:
+		case <-time.After(500 * time.Millisecond):
+			t.Fatal("expected an export after the schedule delay")
+		}
+	})
+
+	t.Run("drops records beyond the max queue size", func(t *testing.T) {
+		exporter := newTestLogExporter()
+		exporter.block = make(chan struct{})
+		processor := log.NewBatchProcessor(exporter, newBatchLogProcessorOptions(&OTLPConfig{
+			LogsMaxQueueSize:       uintPtr(3),
+			LogsMaxExportBatchSize: uintPtr(3),
+			LogsScheduleDelay:      uintPtr(3600000),
+		})...)
+
+		emitTestLogRecords(t, processor, 50)
+		close(exporter.block)
+
+		if err := processor.Shutdown(context.Background()); err != nil {
+			t.Fatalf("failed to shutdown processor: %v", err)
+		}
+
+		batches, _ := exporter.getExported()
+
+		var total int
+		for _, size := range batches {
+			total += size
+		}
+
+		// the blocked export, the export buffer and the queue hold 3 records each.
+		if total == 0 || total > 9 {
+			t.Errorf("expected at most 9 exported records, got %d", total)
+		}
+	})
+}
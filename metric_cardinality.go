@@ -0,0 +1,160 @@
+package gotel
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// attribute of the series that the SDK folds excess attribute sets into when the cardinality limit is exceeded.
+	metricOverflowAttributeKey   = attribute.Key("otel.metric.overflow")
+	metricOverflowMetricName     = "gotel.metric.cardinality_overflows"
+	metricOverflowMetricUnit     = "{collection}"
+	metricOverflowMetricNameAttr = attribute.Key("otel.metric.name")
+)
+
+// metricOverflowCounter counts collections in which metrics contain the overflow series per instrument name.
+// It is the exporter of a dedicated periodic reader, so the count uses instrument names before any translation
+// and does not depend on the number or the kind of the configured readers.
+type metricOverflowCounter struct {
+	counts map[string]int64
+	mu     sync.Mutex
+}
+
+func newMetricOverflowCounter() *metricOverflowCounter {
+	return &metricOverflowCounter{
+		counts: map[string]int64{},
+	}
+}
+
+// Temporality returns the cumulative temporality, so the overflow series of the other cumulative readers
+// is also seen by the counter.
+func (moc *metricOverflowCounter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return metric.DefaultTemporalitySelector(kind)
+}
+
+// Aggregation returns the default aggregation of the instrument kind, except for histograms
+// that are summed because the counter only needs attribute sets instead of buckets.
+func (moc *metricOverflowCounter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	if kind == metric.InstrumentKindHistogram {
+		return metric.AggregationSum{}
+	}
+
+	return metric.DefaultAggregationSelector(kind)
+}
+
+// Export records the collected resource metrics.
+func (moc *metricOverflowCounter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	moc.record(rm)
+
+	return nil
+}
+
+// ForceFlush does nothing because the counter exports nothing.
+func (moc *metricOverflowCounter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown does nothing because the counter exports nothing.
+func (moc *metricOverflowCounter) Shutdown(context.Context) error {
+	return nil
+}
+
+// record increases the count of every metric that has a data point of the overflow series.
+func (moc *metricOverflowCounter) record(rm *metricdata.ResourceMetrics) {
+	moc.mu.Lock()
+	defer moc.mu.Unlock()
+
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if hasMetricOverflow(m.Data) {
+				moc.counts[m.Name]++
+			}
+		}
+	}
+}
+
+// register the observable counter that reports overflow counts on the meter.
+func (moc *metricOverflowCounter) register(meter metricapi.Meter) error {
+	_, err := meter.Int64ObservableCounter(
+		metricOverflowMetricName,
+		metricapi.WithUnit(metricOverflowMetricUnit),
+		metricapi.WithDescription(
+			"Number of collections in which a metric exceeded the cardinality limit "+
+				"and folded excess attribute sets into the otel.metric.overflow series",
+		),
+		metricapi.WithInt64Callback(func(_ context.Context, observer metricapi.Int64Observer) error {
+			moc.mu.Lock()
+			defer moc.mu.Unlock()
+
+			for name, count := range moc.counts {
+				observer.Observe(count, metricapi.WithAttributes(metricOverflowMetricNameAttr.String(name)))
+			}
+
+			return nil
+		}),
+	)
+
+	return err
+}
+
+func hasMetricOverflow(data metricdata.Aggregation) bool {
+	switch d := data.(type) {
+	case metricdata.Sum[int64]:
+		return hasDataPointOverflow(d.DataPoints, func(dp metricdata.DataPoint[int64]) attribute.Set {
+			return dp.Attributes
+		})
+	case metricdata.Sum[float64]:
+		return hasDataPointOverflow(d.DataPoints, func(dp metricdata.DataPoint[float64]) attribute.Set {
+			return dp.Attributes
+		})
+	case metricdata.Gauge[int64]:
+		return hasDataPointOverflow(d.DataPoints, func(dp metricdata.DataPoint[int64]) attribute.Set {
+			return dp.Attributes
+		})
+	case metricdata.Gauge[float64]:
+		return hasDataPointOverflow(d.DataPoints, func(dp metricdata.DataPoint[float64]) attribute.Set {
+			return dp.Attributes
+		})
+	case metricdata.Histogram[int64]:
+		return hasDataPointOverflow(d.DataPoints, func(dp metricdata.HistogramDataPoint[int64]) attribute.Set {
+			return dp.Attributes
+		})
+	case metricdata.Histogram[float64]:
+		return hasDataPointOverflow(d.DataPoints, func(dp metricdata.HistogramDataPoint[float64]) attribute.Set {
+			return dp.Attributes
+		})
+	case metricdata.ExponentialHistogram[int64]:
+		return hasDataPointOverflow(
+			d.DataPoints,
+			func(dp metricdata.ExponentialHistogramDataPoint[int64]) attribute.Set {
+				return dp.Attributes
+			},
+		)
+	case metricdata.ExponentialHistogram[float64]:
+		return hasDataPointOverflow(
+			d.DataPoints,
+			func(dp metricdata.ExponentialHistogramDataPoint[float64]) attribute.Set {
+				return dp.Attributes
+			},
+		)
+	default:
+		return false
+	}
+}
+
+func hasDataPointOverflow[T any](dataPoints []T, getAttributes func(T) attribute.Set) bool {
+	for _, dp := range dataPoints {
+		attrs := getAttributes(dp)
+		if value, ok := attrs.Value(metricOverflowAttributeKey); ok && value.AsBool() {
+			return true
+		}
+	}
+
+	return false
+}
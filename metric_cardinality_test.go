@@ -0,0 +1,329 @@
+package gotel
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// testMetricExporter records the names of exported metrics.
+type testMetricExporter struct {
+	names []string
+	mu    sync.Mutex
+}
+
+func (tme *testMetricExporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return metric.DefaultTemporalitySelector(kind)
+}
+
+func (tme *testMetricExporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return metric.DefaultAggregationSelector(kind)
+}
+
+func (tme *testMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	tme.mu.Lock()
+	defer tme.mu.Unlock()
+
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			tme.names = append(tme.names, m.Name)
+		}
+	}
+
+	return nil
+}
+
+func (tme *testMetricExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (tme *testMetricExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// returns the values of the overflow counter by metric name.
+func getTestOverflowCounts(t *testing.T, metrics metricdata.ResourceMetrics) map[string]int64 {
+	t.Helper()
+
+	counts := map[string]int64{}
+
+	for _, scopeMetrics := range metrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if m.Name != metricOverflowMetricName {
+				continue
+			}
+
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok || !sum.IsMonotonic {
+				t.Fatalf("expected a monotonic counter of the overflow metric, got %+v", m.Data)
+			}
+
+			for _, dp := range sum.DataPoints {
+				name, _ := dp.Attributes.Value(metricOverflowMetricNameAttr)
+				counts[name.AsString()] = dp.Value
+			}
+		}
+	}
+
+	return counts
+}
+
+func TestSetupOTelExporters_CardinalityLimit(t *testing.T) {
+	limit := 3
+	metricReader := metric.NewManualReader()
+	metricExporters := []*testMetricExporter{{}, {}}
+
+	exporters, err := SetupOTelExporters(
+		context.Background(),
+		&OTLPConfig{
+			ServiceName:             "cardinality-test",
+			TracesExporter:          OTELTracesExporterNone,
+			MetricsCardinalityLimit: &limit,
+			DisableGoMetrics:        boolPtr(true),
+		},
+		"v1.0.0",
+		slog.Default(),
+		WithGlobalRegistration(false),
+		WithMetricReader(metricReader),
+		WithMetricExporter(metricExporters[0]),
+		WithMetricExporter(metricExporters[1]),
+	)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	defer exporters.Shutdown(context.Background())
+
+	counter, err := exporters.Meter.Int64Counter("http.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	for i := range 10 {
+		counter.Add(context.Background(), 1, metricapi.WithAttributes(attribute.String("url.path", "/"+strconv.Itoa(i))))
+	}
+
+	// every flush collects the reader of the overflow counter once, regardless of the number of exporters.
+	for range 3 {
+		if err := exporters.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("failed to flush exporters: %v", err)
+		}
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := metricReader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	var requests *metricdata.Metrics
+
+	for _, scopeMetrics := range metrics.ScopeMetrics {
+		for i, m := range scopeMetrics.Metrics {
+			if m.Name == "http.requests" {
+				requests = &scopeMetrics.Metrics[i]
+			}
+		}
+	}
+
+	if requests == nil {
+		t.Fatal("expected the http.requests metric")
+	}
+
+	sum, ok := requests.Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != limit {
+		t.Fatalf("expected %d data points of http.requests, got %v", limit, requests.Data)
+	}
+
+	if !hasMetricOverflow(requests.Data) {
+		t.Errorf("expected the overflow series, got %v", sum.DataPoints)
+	}
+
+	for i, metricExporter := range metricExporters {
+		if !slices.Contains(metricExporter.names, "http.requests") {
+			t.Errorf("expected exporter %d to export http.requests, got %v", i, metricExporter.names)
+		}
+	}
+
+	counts := getTestOverflowCounts(t, metrics)
+	if len(counts) != 1 || counts["http.requests"] != 3 {
+		t.Errorf("expected 3 overflowed collections of http.requests, got %v", counts)
+	}
+}
+
+func TestSetupOTelExporters_CardinalityLimitPrometheus(t *testing.T) {
+	limit := 2
+
+	exporters, err := SetupOTelExporters(
+		context.Background(),
+		&OTLPConfig{
+			ServiceName:             "cardinality-test",
+			TracesExporter:          OTELTracesExporterNone,
+			MetricsExporter:         OTELMetricsExporterPrometheus,
+			MetricsCardinalityLimit: &limit,
+			DisableGoMetrics:        boolPtr(true),
+		},
+		"v1.0.0",
+		slog.Default(),
+		WithGlobalRegistration(false),
+	)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	defer exporters.Shutdown(context.Background())
+
+	counter, err := exporters.Meter.Int64Counter("http.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	for i := range 5 {
+		counter.Add(context.Background(), 1, metricapi.WithAttributes(attribute.String("url.path", "/"+strconv.Itoa(i))))
+	}
+
+	// the flush collects the reader of the overflow counter that is reported by the next scrape.
+	if err := exporters.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("failed to flush exporters: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	exporters.PrometheusHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+
+	// the label holds the instrument name instead of the Prometheus metric name.
+	expected := `gotel_metric_cardinality_overflows_total{otel_metric_name="http.requests",`
+	if !strings.Contains(body, expected) {
+		t.Errorf("expected %s, got: %s", expected, body)
+	}
+}
+
+func TestMetricOverflowCounter(t *testing.T) {
+	overflowAttributes := attribute.NewSet(metricOverflowAttributeKey.Bool(true))
+	newResourceMetrics := func(name string, attrs attribute.Set) *metricdata.ResourceMetrics {
+		return &metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Metrics: []metricdata.Metrics{{
+					Name: name,
+					Data: metricdata.Sum[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 1}},
+					},
+				}},
+			}},
+		}
+	}
+
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	defer meterProvider.Shutdown(context.Background())
+
+	counter := newMetricOverflowCounter()
+	if err := counter.register(meterProvider.Meter(instrumentationScopeName)); err != nil {
+		t.Fatalf("failed to register the overflow counter: %v", err)
+	}
+
+	steps := []struct {
+		Name     string
+		Record   func()
+		Expected map[string]int64
+	}{
+		{
+			Name:     "does not report metrics that never overflowed",
+			Record:   func() { counter.record(newResourceMetrics("requests", *attribute.EmptySet())) },
+			Expected: map[string]int64{},
+		},
+		{
+			Name:     "counts the overflow",
+			Record:   func() { counter.record(newResourceMetrics("requests", overflowAttributes)) },
+			Expected: map[string]int64{"requests": 1},
+		},
+		{
+			Name:     "keeps the count after the recovery",
+			Record:   func() { counter.record(newResourceMetrics("requests", *attribute.EmptySet())) },
+			Expected: map[string]int64{"requests": 1},
+		},
+		{
+			Name: "counts every overflowed collection",
+			Record: func() {
+				counter.record(newResourceMetrics("requests", overflowAttributes))
+				counter.record(newResourceMetrics("jobs", overflowAttributes))
+			},
+			Expected: map[string]int64{"requests": 2, "jobs": 1},
+		},
+	}
+
+	for _, step := range steps {
+		step.Record()
+
+		var metrics metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &metrics); err != nil {
+			t.Fatalf("failed to collect metrics: %v", err)
+		}
+
+		if counts := getTestOverflowCounts(t, metrics); !maps.Equal(counts, step.Expected) {
+			t.Errorf("%s: expected %v, got %v", step.Name, step.Expected, counts)
+		}
+	}
+}
+
+func TestHasMetricOverflow(t *testing.T) {
+	overflowAttributes := attribute.NewSet(metricOverflowAttributeKey.Bool(true))
+
+	testCases := []struct {
+		Name     string
+		Data     metricdata.Aggregation
+		Expected bool
+	}{
+		{
+			Name: "sum without overflow",
+			Data: metricdata.Sum[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{{Attributes: attribute.NewSet(attribute.String("a", "b"))}},
+			},
+		},
+		{
+			Name: "gauge with overflow",
+			Data: metricdata.Gauge[float64]{
+				DataPoints: []metricdata.DataPoint[float64]{{Attributes: overflowAttributes}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "histogram with overflow",
+			Data: metricdata.Histogram[float64]{
+				DataPoints: []metricdata.HistogramDataPoint[float64]{{Attributes: overflowAttributes}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "exponential histogram with overflow",
+			Data: metricdata.ExponentialHistogram[int64]{
+				DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{Attributes: overflowAttributes}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "summary",
+			Data: metricdata.Summary{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if result := hasMetricOverflow(tc.Data); result != tc.Expected {
+				t.Errorf("expected %v, got %v", tc.Expected, result)
+			}
+		})
+	}
+}
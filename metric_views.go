@@ -0,0 +1,158 @@
+package gotel
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	// default limits of the base-2 exponential bucket histogram of the OpenTelemetry specification.
+	defaultExponentialHistogramMaxSize  = 160
+	defaultExponentialHistogramMaxScale = 20
+)
+
+// default bucket boundaries of the explicit bucket histogram of the OpenTelemetry specification.
+// They are applied to declarative views without boundaries only, because they fit durations in milliseconds.
+var defaultHistogramBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// create metric views from the view configurations.
+func newMetricViews(configs []MetricViewConfig) ([]metric.View, error) {
+	views := make([]metric.View, 0, len(configs))
+	errs := []error{}
+
+	for i, config := range configs {
+		err := config.validate(fmt.Sprintf("metricViews[%d]", i))
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		views = append(views, config.toView())
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return views, nil
+}
+
+// validate the view configuration. Errors are [ConfigFieldError]s of fields under the path.
+func (mvc MetricViewConfig) validate(path string) error {
+	errs := []error{
+		validateEnum(path+".aggregation", mvc.Aggregation, otelMetricAggregationTypes, ErrInvalidOTELMetricAggregationType),
+	}
+
+	if mvc.InstrumentName == "" {
+		errs = append(errs, newConfigFieldError(
+			path+".instrumentName",
+			fmt.Errorf("%w: instrument name is required", ErrInvalidMetricView),
+		))
+	}
+
+	if mvc.Name != "" && strings.ContainsAny(mvc.InstrumentName, "*?") {
+		errs = append(errs, newConfigFieldError(
+			path+".name",
+			fmt.Errorf("%w: cannot rename instruments that are matched by wildcards", ErrInvalidMetricView),
+		))
+	}
+
+	if mvc.Drop && mvc.Aggregation != "" && mvc.Aggregation != OTELMetricAggregationDrop {
+		errs = append(errs, newConfigFieldError(
+			path+".drop",
+			fmt.Errorf("%w: drop conflicts with the %s aggregation", ErrInvalidMetricView, mvc.Aggregation),
+		))
+	}
+
+	if mvc.Aggregation == OTELMetricAggregationExplicitBucketHistogram && len(mvc.Buckets) == 0 {
+		errs = append(errs, newConfigFieldError(
+			path+".buckets",
+			fmt.Errorf("%w: the explicit_bucket_histogram aggregation requires buckets", ErrInvalidMetricView),
+		))
+	}
+
+	if len(mvc.Buckets) > 0 {
+		if mvc.Aggregation != "" && mvc.Aggregation != OTELMetricAggregationExplicitBucketHistogram {
+			errs = append(errs, newConfigFieldError(
+				path+".buckets",
+				fmt.Errorf("%w: buckets require the explicit_bucket_histogram aggregation", ErrInvalidMetricView),
+			))
+		}
+
+		for i := 1; i < len(mvc.Buckets); i++ {
+			if mvc.Buckets[i] <= mvc.Buckets[i-1] {
+				errs = append(errs, newConfigFieldError(
+					path+".buckets",
+					fmt.Errorf("%w: buckets must be strictly increasing", ErrInvalidMetricView),
+				))
+
+				break
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (mvc MetricViewConfig) toView() metric.View {
+	stream := metric.Stream{
+		Name:        mvc.Name,
+		Description: mvc.Description,
+		Aggregation: mvc.getAggregation(),
+	}
+
+	if len(mvc.AttributeKeys) > 0 {
+		keys := make([]attribute.Key, len(mvc.AttributeKeys))
+		for i, key := range mvc.AttributeKeys {
+			keys[i] = attribute.Key(key)
+		}
+
+		stream.AttributeFilter = attribute.NewAllowKeysFilter(keys...)
+	}
+
+	return metric.NewView(
+		metric.Instrument{
+			Name:  mvc.InstrumentName,
+			Scope: instrumentation.Scope{Name: mvc.MeterName},
+		},
+		stream,
+	)
+}
+
+// returns the aggregation of the view, or nil to use the default aggregation of the instrument kind.
+func (mvc MetricViewConfig) getAggregation() metric.Aggregation {
+	if mvc.Drop {
+		return metric.AggregationDrop{}
+	}
+
+	switch mvc.Aggregation {
+	case OTELMetricAggregationDrop:
+		return metric.AggregationDrop{}
+	case OTELMetricAggregationSum:
+		return metric.AggregationSum{}
+	case OTELMetricAggregationLastValue:
+		return metric.AggregationLastValue{}
+	case OTELMetricAggregationExplicitBucketHistogram:
+		return metric.AggregationExplicitBucketHistogram{Boundaries: slices.Clone(mvc.Buckets)}
+	case OTELMetricAggregationBase2ExponentialBucketHistogram:
+		return metric.AggregationBase2ExponentialHistogram{
+			MaxSize:  defaultExponentialHistogramMaxSize,
+			MaxScale: defaultExponentialHistogramMaxScale,
+		}
+	case "":
+		if len(mvc.Buckets) > 0 {
+			return metric.AggregationExplicitBucketHistogram{Boundaries: slices.Clone(mvc.Buckets)}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,202 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricViewConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        MetricViewConfig
+		ExpectedPath  string
+		ExpectedError error
+	}{
+		{
+			Name: "valid view",
+			Config: MetricViewConfig{
+				InstrumentName: "http.server.*",
+				Aggregation:    OTELMetricAggregationExplicitBucketHistogram,
+				Buckets:        []float64{0.1, 0.5, 1},
+				AttributeKeys:  []string{"http.route"},
+			},
+		},
+		{
+			Name:          "instrument name required",
+			Config:        MetricViewConfig{Drop: true},
+			ExpectedPath:  "view.instrumentName",
+			ExpectedError: ErrInvalidMetricView,
+		},
+		{
+			Name:          "invalid aggregation",
+			Config:        MetricViewConfig{InstrumentName: "requests", Aggregation: "summary"},
+			ExpectedPath:  "view.aggregation",
+			ExpectedError: ErrInvalidOTELMetricAggregationType,
+		},
+		{
+			Name:          "rename wildcard instruments",
+			Config:        MetricViewConfig{InstrumentName: "http.*", Name: "http"},
+			ExpectedPath:  "view.name",
+			ExpectedError: ErrInvalidMetricView,
+		},
+		{
+			Name: "drop with another aggregation",
+			Config: MetricViewConfig{
+				InstrumentName: "requests",
+				Aggregation:    OTELMetricAggregationSum,
+				Drop:           true,
+			},
+			ExpectedPath:  "view.drop",
+			ExpectedError: ErrInvalidMetricView,
+		},
+		{
+			Name: "buckets with another aggregation",
+			Config: MetricViewConfig{
+				InstrumentName: "requests",
+				Aggregation:    OTELMetricAggregationLastValue,
+				Buckets:        []float64{1, 2},
+			},
+			ExpectedPath:  "view.buckets",
+			ExpectedError: ErrInvalidMetricView,
+		},
+		{
+			Name: "explicit bucket histogram without buckets",
+			Config: MetricViewConfig{
+				InstrumentName: "http.server.request.duration",
+				Aggregation:    OTELMetricAggregationExplicitBucketHistogram,
+			},
+			ExpectedPath:  "view.buckets",
+			ExpectedError: ErrInvalidMetricView,
+		},
+		{
+			Name:          "unsorted buckets",
+			Config:        MetricViewConfig{InstrumentName: "requests", Buckets: []float64{1, 1, 2}},
+			ExpectedPath:  "view.buckets",
+			ExpectedError: ErrInvalidMetricView,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Config.validate("view")
+			if tc.ExpectedError == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tc.ExpectedError) {
+				t.Fatalf("expected error %v, got: %v", tc.ExpectedError, err)
+			}
+
+			var fieldErr *ConfigFieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("expected ConfigFieldError, got: %T", err)
+			}
+
+			if fieldErr.Path != tc.ExpectedPath {
+				t.Errorf("expected path %s, got %s", tc.ExpectedPath, fieldErr.Path)
+			}
+		})
+	}
+}
+
+func TestNewMetricViews(t *testing.T) {
+	views, err := newMetricViews([]MetricViewConfig{
+		{InstrumentName: "requests", Name: "http.requests", AttributeKeys: []string{"method"}},
+		{InstrumentName: "debug.*", Drop: true},
+		{InstrumentName: "latency", MeterName: "api", Buckets: []float64{10, 100}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader), metric.WithView(views...))
+
+	defer meterProvider.Shutdown(context.Background())
+
+	meter := meterProvider.Meter("api")
+
+	requests, err := meter.Int64Counter("requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	debug, err := meter.Int64Counter("debug.calls")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	latency, err := meter.Float64Histogram("latency")
+	if err != nil {
+		t.Fatalf("failed to create histogram: %v", err)
+	}
+
+	requests.Add(
+		context.Background(),
+		1,
+		metricapi.WithAttributes(attribute.String("method", "GET"), attribute.String("path", "/users")),
+	)
+	debug.Add(context.Background(), 1)
+	latency.Record(context.Background(), 50)
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	if len(metrics.ScopeMetrics) != 1 {
+		t.Fatalf("expected 1 scope, got %d", len(metrics.ScopeMetrics))
+	}
+
+	results := map[string]metricdata.Aggregation{}
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		results[m.Name] = m.Data
+	}
+
+	if _, ok := results["debug.calls"]; ok {
+		t.Error("expected debug.calls to be dropped")
+	}
+
+	if _, ok := results["requests"]; ok {
+		t.Error("expected requests to be renamed")
+	}
+
+	sum, ok := results["http.requests"].(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 {
+		t.Fatalf("expected 1 data point of http.requests, got %v", results["http.requests"])
+	}
+
+	if sum.DataPoints[0].Attributes.Len() != 1 || !sum.DataPoints[0].Attributes.HasValue("method") {
+		t.Errorf("expected the method attribute only, got %v", sum.DataPoints[0].Attributes.ToSlice())
+	}
+
+	histogram, ok := results["latency"].(metricdata.Histogram[float64])
+	if !ok || len(histogram.DataPoints) != 1 {
+		t.Fatalf("expected 1 data point of latency, got %v", results["latency"])
+	}
+
+	if !slices.Equal(histogram.DataPoints[0].Bounds, []float64{10, 100}) {
+		t.Errorf("expected bounds [10 100], got %v", histogram.DataPoints[0].Bounds)
+	}
+}
+
+func TestNewMetricViews_InvalidConfig(t *testing.T) {
+	_, err := newMetricViews([]MetricViewConfig{
+		{InstrumentName: "requests"},
+		{Aggregation: "summary"},
+	})
+	if !errors.Is(err, ErrInvalidMetricView) || !errors.Is(err, ErrInvalidOTELMetricAggregationType) {
+		t.Errorf("expected invalid metric view errors, got: %v", err)
+	}
+}
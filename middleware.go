@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/hasura/gotel/otelutils"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
@@ -139,7 +138,7 @@ func (tm *tracingMiddleware) ServeHTTP( //nolint:gocognit,cyclop,funlen,maintidx
 
 	if !slices.Contains(tm.Options.DebugPaths, urlPath) {
 		ctx, span = tm.Exporters.Tracer.Start(
-			otel.GetTextMapPropagator().
+			tm.Exporters.getPropagator().
 				Extract(r.Context(), propagation.HeaderCarrier(r.Header)),
 			tm.Options.getRequestSpanName(r),
 			trace.WithSpanKind(trace.SpanKindServer),
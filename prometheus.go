@@ -0,0 +1,102 @@
+package gotel
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/otlptranslator"
+	otelPrometheus "go.opentelemetry.io/otel/exporters/prometheus"
+)
+
+const (
+	prometheusMetricsPath             = "/metrics"
+	prometheusServerReadHeaderTimeout = 10 * time.Second
+)
+
+// create a dedicated Prometheus registry of the exporter, so multiple pipelines can run in the same process.
+// The process collector is registered unless Go metrics are disabled. The Go collector is not registered
+// because the Go runtime metrics are recorded by OpenTelemetry.
+// Returns nil if metrics are not exported in the Prometheus format.
+func newPrometheusRegistry(config *OTLPConfig) *prometheus.Registry {
+	exporterType := config.GetMetricsExporter()
+	if exporterType != OTELMetricsExporterPrometheus && exporterType != OTELMetricsExporterPushgateway {
+		return nil
+	}
+
+	registry := prometheus.NewRegistry()
+
+	if config.DisableGoMetrics == nil || !*config.DisableGoMetrics {
+		registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	}
+
+	return registry
+}
+
+// create the Prometheus exporter that registers into the registry with naming options of the configuration.
+func newPrometheusExporter(config *OTLPConfig, registry prometheus.Registerer) (*otelPrometheus.Exporter, error) {
+	options := []otelPrometheus.Option{
+		otelPrometheus.WithRegisterer(registry),
+		otelPrometheus.WithNamespace(config.PrometheusNamespace),
+		otelPrometheus.WithTranslationStrategy(
+			otlptranslator.TranslationStrategyOption(config.GetPrometheusTranslationStrategy()),
+		),
+	}
+
+	if config.PrometheusWithoutScopeInfo != nil && *config.PrometheusWithoutScopeInfo {
+		options = append(options, otelPrometheus.WithoutScopeInfo())
+	}
+
+	if config.PrometheusWithoutTargetInfo != nil && *config.PrometheusWithoutTargetInfo {
+		options = append(options, otelPrometheus.WithoutTargetInfo())
+	}
+
+	return otelPrometheus.New(options...)
+}
+
+// create the handler of the Prometheus registry like [promhttp.Handler].
+// The OpenMetrics format is negotiated if the scraper accepts it, so exemplars that link metrics to traces are exposed.
+func newPrometheusHandler(registry *prometheus.Registry) http.Handler {
+	return promhttp.InstrumentMetricHandler(
+		registry,
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		}),
+	)
+}
+
+// start a dedicated HTTP server that serves Prometheus metrics on the /metrics endpoint.
+// The listener is opened synchronously so that port conflicts are reported to the caller.
+func startPrometheusServer(
+	port uint,
+	handler http.Handler,
+	logger *slog.Logger,
+) (*http.Server, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(prometheusMetricsPath, handler)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: prometheusServerReadHeaderTimeout,
+	}
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("failed to serve Prometheus metrics: " + err.Error())
+		}
+	}()
+
+	return server, nil
+}
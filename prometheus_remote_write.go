@@ -0,0 +1,581 @@
+package gotel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/otlptranslator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// default path of the remote-write receiver that is appended to the general OTLP endpoint.
+	remoteWriteDefaultPath      = "/api/v1/write"
+	remoteWriteProtocolVersion  = "0.1.0"
+	remoteWriteMaxErrorBodySize = 512
+
+	prometheusMetricNameLabel   = "__name__"
+	prometheusBucketLabel       = "le"
+	prometheusJobLabel          = "job"
+	prometheusInstanceLabel     = "instance"
+	prometheusScopeNameLabel    = "otel_scope_name"
+	prometheusScopeVersionLabel = "otel_scope_version"
+	// prefix of data-point labels that conflict with target or scope labels.
+	prometheusExportedLabelPrefix = "exported_"
+)
+
+// metric types of the remote-write metadata.
+const (
+	remoteWriteMetricTypeCounter   uint64 = 1
+	remoteWriteMetricTypeGauge     uint64 = 2
+	remoteWriteMetricTypeHistogram uint64 = 3
+)
+
+var (
+	errRemoteWriteFailed           = errors.New("remote write request failed")
+	errRemoteWriteExporterShutdown = errors.New("remote write exporter is shut down")
+)
+
+// remoteWriteExporter converts metrics to samples of the Prometheus remote-write 1.0 protocol
+// and sends them to the endpoint in snappy-compressed protobuf requests.
+// Exponential histograms and summaries are not supported by the protocol version and are dropped.
+// Config views of exponential histograms are rejected by [OTLPConfig.Validate].
+type remoteWriteExporter struct {
+	client           *http.Client
+	endpoint         string
+	headers          map[string]string
+	timeout          time.Duration
+	metricNamer      otlptranslator.MetricNamer
+	labelNamer       otlptranslator.LabelNamer
+	withoutScopeInfo bool
+	shutdown         atomic.Bool
+}
+
+// create the remote-write exporter with the endpoint, headers, timeout and TLS settings of the OTLP metrics exporter.
+// The metrics endpoint is the full remote-write URL. Otherwise the default path is appended to the general endpoint.
+// The insecure option only selects the http scheme of endpoints without scheme, so explicit https URLs keep TLS.
+func newRemoteWriteExporter(config *OTLPConfig) (*remoteWriteExporter, error) {
+	rawEndpoint := config.OtlpMetricsEndpoint
+	useDefaultPath := rawEndpoint == ""
+
+	if useDefaultPath {
+		rawEndpoint = config.OtlpEndpoint
+	}
+
+	if rawEndpoint == "" {
+		return nil, ErrMetricsOTLPEndpointRequired
+	}
+
+	insecure := getDefaultPtr(config.OtlpMetricsInsecure, config.OtlpInsecure)
+	hasScheme := strings.HasPrefix(rawEndpoint, "http://") || strings.HasPrefix(rawEndpoint, "https://")
+
+	if insecure != nil && *insecure && !hasScheme {
+		rawEndpoint = "http://" + rawEndpoint
+	}
+
+	endpoint, _, _, err := parseOTLPEndpoint(rawEndpoint, OTLPProtocolHTTPProtobuf, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the remote-write endpoint: %w", err)
+	}
+
+	if useDefaultPath {
+		endpoint, err = url.JoinPath(endpoint, remoteWriteDefaultPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the remote-write endpoint: %w", err)
+		}
+	}
+
+	tlsConfig, err := newOTLPTLSConfig(
+		getDefault(config.OtlpMetricsCertificate, config.OtlpCertificate),
+		getDefault(config.OtlpMetricsClientCertificate, config.OtlpClientCertificate),
+		getDefault(config.OtlpMetricsClientKey, config.OtlpClientKey),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OTLP metrics TLS certificates: %w", err)
+	}
+
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+
+	strategy := otlptranslator.TranslationStrategyOption(config.GetPrometheusTranslationStrategy())
+
+	return &remoteWriteExporter{
+		client:           &http.Client{Transport: transport},
+		endpoint:         endpoint,
+		headers:          config.GetOTLPMetricsHeaders(),
+		timeout:          config.GetOTLPMetricsTimeout(),
+		metricNamer:      otlptranslator.NewMetricNamer(config.PrometheusNamespace, strategy),
+		labelNamer:       otlptranslator.LabelNamer{UTF8Allowed: !strategy.ShouldEscape()},
+		withoutScopeInfo: config.PrometheusWithoutScopeInfo != nil && *config.PrometheusWithoutScopeInfo,
+	}, nil
+}
+
+// Temporality returns the cumulative temporality that Prometheus expects for all instrument kinds.
+func (rwe *remoteWriteExporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return metric.DefaultTemporalitySelector(kind)
+}
+
+// Aggregation returns the default aggregation of the instrument kind.
+func (rwe *remoteWriteExporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return metric.DefaultAggregationSelector(kind)
+}
+
+// Export converts the resource metrics to remote-write time series and sends them in a single request.
+func (rwe *remoteWriteExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if rwe.shutdown.Load() {
+		return errRemoteWriteExporterShutdown
+	}
+
+	request := rwe.newRequest(rm)
+	if len(request.series) == 0 {
+		return nil
+	}
+
+	return rwe.send(ctx, request.marshal())
+}
+
+// ForceFlush does nothing because the exporter does not buffer metrics.
+func (rwe *remoteWriteExporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown stops exporting metrics and closes idle connections.
+func (rwe *remoteWriteExporter) Shutdown(ctx context.Context) error {
+	rwe.shutdown.Store(true)
+	rwe.client.CloseIdleConnections()
+
+	return ctx.Err()
+}
+
+// send the snappy-compressed protobuf payload to the remote-write endpoint.
+func (rwe *remoteWriteExporter) send(ctx context.Context, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, rwe.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		rwe.endpoint,
+		bytes.NewReader(snappy.Encode(nil, payload)),
+	)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range rwe.headers {
+		req.Header.Set(key, value)
+	}
+
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteProtocolVersion)
+
+	resp, err := rwe.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, remoteWriteMaxErrorBodySize))
+
+	return fmt.Errorf("%w: %s: %s", errRemoteWriteFailed, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// convert the resource metrics to a remote-write request.
+func (rwe *remoteWriteExporter) newRequest(rm *metricdata.ResourceMetrics) *remoteWriteRequest {
+	request := &remoteWriteRequest{familyNames: map[string]bool{}}
+	targetLabels := newRemoteWriteTargetLabels(rm.Resource)
+
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		scopeLabels := maps.Clone(targetLabels)
+
+		if !rwe.withoutScopeInfo {
+			if scopeMetrics.Scope.Name != "" {
+				scopeLabels[prometheusScopeNameLabel] = scopeMetrics.Scope.Name
+			}
+
+			if scopeMetrics.Scope.Version != "" {
+				scopeLabels[prometheusScopeVersionLabel] = scopeMetrics.Scope.Version
+			}
+		}
+
+		for _, m := range scopeMetrics.Metrics {
+			rwe.appendMetric(request, scopeLabels, m)
+		}
+	}
+
+	return request
+}
+
+func (rwe *remoteWriteExporter) appendMetric(
+	request *remoteWriteRequest,
+	scopeLabels map[string]string,
+	m metricdata.Metrics,
+) {
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		rwe.appendSamples(request, scopeLabels, m, getSumMetricType(data.IsMonotonic), toRemoteWriteSamples(data.DataPoints))
+	case metricdata.Sum[float64]:
+		rwe.appendSamples(request, scopeLabels, m, getSumMetricType(data.IsMonotonic), toRemoteWriteSamples(data.DataPoints))
+	case metricdata.Gauge[int64]:
+		rwe.appendSamples(request, scopeLabels, m, otlptranslator.MetricTypeGauge, toRemoteWriteSamples(data.DataPoints))
+	case metricdata.Gauge[float64]:
+		rwe.appendSamples(request, scopeLabels, m, otlptranslator.MetricTypeGauge, toRemoteWriteSamples(data.DataPoints))
+	case metricdata.Histogram[int64]:
+		rwe.appendHistograms(request, scopeLabels, m, toRemoteWriteHistograms(data.DataPoints))
+	case metricdata.Histogram[float64]:
+		rwe.appendHistograms(request, scopeLabels, m, toRemoteWriteHistograms(data.DataPoints))
+	default:
+	}
+}
+
+func (rwe *remoteWriteExporter) appendSamples(
+	request *remoteWriteRequest,
+	scopeLabels map[string]string,
+	m metricdata.Metrics,
+	metricType otlptranslator.MetricType,
+	samples []remoteWriteSample,
+) {
+	name, ok := rwe.appendMetadata(request, m, metricType)
+	if !ok {
+		return
+	}
+
+	for _, sample := range samples {
+		request.appendSeries(name, rwe.newLabels(scopeLabels, sample.attributes), sample.value, sample.timestamp)
+	}
+}
+
+// append histograms as the _bucket, _sum and _count series of classic Prometheus histograms.
+func (rwe *remoteWriteExporter) appendHistograms(
+	request *remoteWriteRequest,
+	scopeLabels map[string]string,
+	m metricdata.Metrics,
+	histograms []remoteWriteHistogram,
+) {
+	name, ok := rwe.appendMetadata(request, m, otlptranslator.MetricTypeHistogram)
+	if !ok {
+		return
+	}
+
+	for _, histogram := range histograms {
+		labels := rwe.newLabels(scopeLabels, histogram.attributes)
+
+		var cumulativeCount uint64
+
+		for i, bound := range histogram.bounds {
+			if i < len(histogram.bucketCounts) {
+				cumulativeCount += histogram.bucketCounts[i]
+			}
+
+			bucketLabels := maps.Clone(labels)
+			bucketLabels[prometheusBucketLabel] = strconv.FormatFloat(bound, 'f', -1, 64)
+			request.appendSeries(name+"_bucket", bucketLabels, float64(cumulativeCount), histogram.timestamp)
+		}
+
+		bucketLabels := maps.Clone(labels)
+		bucketLabels[prometheusBucketLabel] = "+Inf"
+		request.appendSeries(name+"_bucket", bucketLabels, float64(histogram.count), histogram.timestamp)
+		request.appendSeries(name+"_sum", labels, histogram.sum, histogram.timestamp)
+		request.appendSeries(name+"_count", labels, float64(histogram.count), histogram.timestamp)
+	}
+}
+
+// append the metadata of the metric family once and return the translated family name.
+// Returns false if the metric name cannot be translated.
+func (rwe *remoteWriteExporter) appendMetadata(
+	request *remoteWriteRequest,
+	m metricdata.Metrics,
+	metricType otlptranslator.MetricType,
+) (string, bool) {
+	name, err := rwe.metricNamer.Build(otlptranslator.Metric{
+		Name: m.Name,
+		Unit: m.Unit,
+		Type: metricType,
+	})
+	if err != nil || name == "" {
+		return "", false
+	}
+
+	// metrics of the same family in different scopes share the metadata entry of the first metric.
+	if request.familyNames[name] {
+		return name, true
+	}
+
+	request.familyNames[name] = true
+
+	metadataType := remoteWriteMetricTypeGauge
+
+	switch metricType {
+	case otlptranslator.MetricTypeMonotonicCounter:
+		metadataType = remoteWriteMetricTypeCounter
+	case otlptranslator.MetricTypeHistogram:
+		metadataType = remoteWriteMetricTypeHistogram
+	default:
+	}
+
+	request.metadata = append(request.metadata, remoteWriteMetadata{
+		metricType: metadataType,
+		familyName: name,
+		help:       m.Description,
+		unit:       m.Unit,
+	})
+
+	return name, true
+}
+
+// translate attributes of the data point to labels and add the scope labels.
+// Values of attributes that are translated to the same label name are joined with semicolons.
+// Scope and target labels take precedence like the labels of a Prometheus scrape target without honor_labels,
+// so a conflicting data-point label such as job is kept as exported_job.
+func (rwe *remoteWriteExporter) newLabels(scopeLabels map[string]string, attrs attribute.Set) map[string]string {
+	labels := make(map[string]string, attrs.Len()+len(scopeLabels))
+	iter := attrs.Iter()
+
+	for iter.Next() {
+		kv := iter.Attribute()
+
+		name, err := rwe.labelNamer.Build(string(kv.Key))
+		if err != nil {
+			continue
+		}
+
+		if value, ok := labels[name]; ok {
+			labels[name] = value + ";" + kv.Value.Emit()
+		} else {
+			labels[name] = kv.Value.Emit()
+		}
+	}
+
+	for name, value := range scopeLabels {
+		if dataPointValue, ok := labels[name]; ok {
+			labels[prometheusExportedLabelPrefix+name] = dataPointValue
+		}
+
+		labels[name] = value
+	}
+
+	return labels
+}
+
+// create the job and instance labels from service attributes of the resource,
+// the same way that the Prometheus OTLP receiver identifies targets.
+func newRemoteWriteTargetLabels(res *resource.Resource) map[string]string {
+	labels := map[string]string{}
+	if res == nil {
+		return labels
+	}
+
+	attrs := res.Set()
+
+	serviceName, _ := attrs.Value(semconv.ServiceNameKey)
+	serviceNamespace, _ := attrs.Value(semconv.ServiceNamespaceKey)
+	serviceInstanceID, _ := attrs.Value(semconv.ServiceInstanceIDKey)
+
+	job := serviceName.AsString()
+	if job != "" && serviceNamespace.AsString() != "" {
+		job = serviceNamespace.AsString() + "/" + job
+	}
+
+	if job != "" {
+		labels[prometheusJobLabel] = job
+	}
+
+	if instance := serviceInstanceID.AsString(); instance != "" {
+		labels[prometheusInstanceLabel] = instance
+	}
+
+	return labels
+}
+
+func getSumMetricType(isMonotonic bool) otlptranslator.MetricType {
+	if isMonotonic {
+		return otlptranslator.MetricTypeMonotonicCounter
+	}
+
+	return otlptranslator.MetricTypeNonMonotonicCounter
+}
+
+type remoteWriteSample struct {
+	attributes attribute.Set
+	value      float64
+	timestamp  int64
+}
+
+func toRemoteWriteSamples[N int64 | float64](dataPoints []metricdata.DataPoint[N]) []remoteWriteSample {
+	samples := make([]remoteWriteSample, len(dataPoints))
+
+	for i, dp := range dataPoints {
+		samples[i] = remoteWriteSample{
+			attributes: dp.Attributes,
+			value:      float64(dp.Value),
+			timestamp:  dp.Time.UnixMilli(),
+		}
+	}
+
+	return samples
+}
+
+type remoteWriteHistogram struct {
+	attributes   attribute.Set
+	bounds       []float64
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+	timestamp    int64
+}
+
+func toRemoteWriteHistograms[N int64 | float64](
+	dataPoints []metricdata.HistogramDataPoint[N],
+) []remoteWriteHistogram {
+	histograms := make([]remoteWriteHistogram, len(dataPoints))
+
+	for i, dp := range dataPoints {
+		histograms[i] = remoteWriteHistogram{
+			attributes:   dp.Attributes,
+			bounds:       dp.Bounds,
+			bucketCounts: dp.BucketCounts,
+			count:        dp.Count,
+			sum:          float64(dp.Sum),
+			timestamp:    dp.Time.UnixMilli(),
+		}
+	}
+
+	return histograms
+}
+
+type remoteWriteLabel struct {
+	name  string
+	value string
+}
+
+// remoteWriteSeries is a time series with a single sample.
+type remoteWriteSeries struct {
+	labels    []remoteWriteLabel
+	value     float64
+	timestamp int64
+}
+
+type remoteWriteMetadata struct {
+	metricType uint64
+	familyName string
+	help       string
+	unit       string
+}
+
+// remoteWriteRequest contains time series and metadata of the prometheus.WriteRequest message.
+type remoteWriteRequest struct {
+	series      []remoteWriteSeries
+	metadata    []remoteWriteMetadata
+	familyNames map[string]bool
+}
+
+// append a series with labels sorted by name, as required by the remote-write protocol.
+func (rwr *remoteWriteRequest) appendSeries(name string, labels map[string]string, value float64, timestamp int64) {
+	series := remoteWriteSeries{
+		labels:    make([]remoteWriteLabel, 0, len(labels)+1),
+		value:     value,
+		timestamp: timestamp,
+	}
+
+	series.labels = append(series.labels, remoteWriteLabel{name: prometheusMetricNameLabel, value: name})
+
+	for labelName, labelValue := range labels {
+		series.labels = append(series.labels, remoteWriteLabel{name: labelName, value: labelValue})
+	}
+
+	slices.SortFunc(series.labels, func(a, b remoteWriteLabel) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	rwr.series = append(rwr.series, series)
+}
+
+// encode the request in the protobuf wire format of the prometheus.WriteRequest message.
+func (rwr *remoteWriteRequest) marshal() []byte {
+	var buf []byte
+
+	for _, series := range rwr.series {
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, series.marshal())
+	}
+
+	for _, metadata := range rwr.metadata {
+		buf = protowire.AppendTag(buf, 3, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, metadata.marshal())
+	}
+
+	return buf
+}
+
+// encode the series in the protobuf wire format of the prometheus.TimeSeries message.
+func (rws remoteWriteSeries) marshal() []byte {
+	var buf []byte
+
+	for _, label := range rws.labels {
+		var labelBuf []byte
+
+		labelBuf = protowire.AppendTag(labelBuf, 1, protowire.BytesType)
+		labelBuf = protowire.AppendString(labelBuf, label.name)
+		labelBuf = protowire.AppendTag(labelBuf, 2, protowire.BytesType)
+		labelBuf = protowire.AppendString(labelBuf, label.value)
+
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, labelBuf)
+	}
+
+	var sampleBuf []byte
+
+	sampleBuf = protowire.AppendTag(sampleBuf, 1, protowire.Fixed64Type)
+	sampleBuf = protowire.AppendFixed64(sampleBuf, math.Float64bits(rws.value))
+	sampleBuf = protowire.AppendTag(sampleBuf, 2, protowire.VarintType)
+	sampleBuf = protowire.AppendVarint(sampleBuf, uint64(rws.timestamp)) //nolint:gosec
+
+	buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, sampleBuf)
+
+	return buf
+}
+
+// encode the metadata in the protobuf wire format of the prometheus.MetricMetadata message.
+func (rwm remoteWriteMetadata) marshal() []byte {
+	var buf []byte
+
+	buf = protowire.AppendTag(buf, 1, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, rwm.metricType)
+	buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+	buf = protowire.AppendString(buf, rwm.familyName)
+	buf = protowire.AppendTag(buf, 4, protowire.BytesType)
+	buf = protowire.AppendString(buf, rwm.help)
+	buf = protowire.AppendTag(buf, 5, protowire.BytesType)
+	buf = protowire.AppendString(buf, rwm.unit)
+
+	return buf
+}
@@ -0,0 +1,595 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"go.opentelemetry.io/otel/attribute"
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type testRemoteWriteSeries struct {
+	Labels    map[string]string
+	Value     float64
+	Timestamp int64
+}
+
+type testRemoteWriteRequest struct {
+	Path     string
+	Header   http.Header
+	Series   []testRemoteWriteSeries
+	Metadata map[string]uint64
+}
+
+// returns the value of the series that has the metric name and all the labels.
+func (trr testRemoteWriteRequest) getValue(name string, labels map[string]string) (float64, bool) {
+	for _, series := range trr.Series {
+		if series.Labels[prometheusMetricNameLabel] != name {
+			continue
+		}
+
+		matched := true
+
+		for key, value := range labels {
+			if series.Labels[key] != value {
+				matched = false
+
+				break
+			}
+		}
+
+		if matched {
+			return series.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+// testRemoteWriteReceiver decodes remote-write requests in place of a Prometheus server.
+type testRemoteWriteReceiver struct {
+	*httptest.Server
+
+	requests []testRemoteWriteRequest
+	mu       sync.Mutex
+}
+
+func newTestRemoteWriteReceiver(t *testing.T) *testRemoteWriteReceiver {
+	t.Helper()
+
+	receiver := &testRemoteWriteReceiver{}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		compressed, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+
+		payload, err := snappy.Decode(nil, compressed)
+		if err != nil {
+			t.Errorf("failed to decode snappy payload: %v", err)
+		}
+
+		request := decodeTestRemoteWriteRequest(t, payload)
+		request.Path = r.URL.Path
+		request.Header = r.Header.Clone()
+
+		receiver.mu.Lock()
+		receiver.requests = append(receiver.requests, request)
+		receiver.mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	t.Cleanup(receiver.Close)
+
+	return receiver
+}
+
+func (trr *testRemoteWriteReceiver) getRequests() []testRemoteWriteRequest {
+	trr.mu.Lock()
+	defer trr.mu.Unlock()
+
+	return append([]testRemoteWriteRequest{}, trr.requests...)
+}
+
+func TestSetupOTelExporters_PrometheusRemoteWrite(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	receiver := newTestRemoteWriteReceiver(t)
+
+	config := &OTLPConfig{
+		ServiceName:         "remote-write-test",
+		ServiceNamespace:    "batch",
+		ServiceInstanceID:   "worker-1",
+		MetricsExporter:     OTELMetricsExporterPrometheusRemoteWrite,
+		OtlpMetricsEndpoint: receiver.URL + "/api/v1/push",
+		OtlpMetricsHeaders:  map[string]string{"X-Scope-OrgID": "tenant-1"},
+		DisableGoMetrics:    boolPtr(true),
+	}
+
+	exporters, err := SetupOTelExporters(
+		context.Background(),
+		config,
+		"v1.0.0",
+		logger,
+		WithGlobalRegistration(false),
+	)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+	defer exporters.Shutdown(context.Background())
+
+	counter, err := exporters.Meter.Int64Counter("remote_write_test.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	counter.Add(context.Background(), 2, metricapi.WithAttributes(attribute.String("http.method", "GET")))
+
+	histogram, err := exporters.Meter.Float64Histogram("remote_write_test.duration", metricapi.WithUnit("s"))
+	if err != nil {
+		t.Fatalf("failed to create histogram: %v", err)
+	}
+
+	histogram.Record(context.Background(), 1.5)
+	histogram.Record(context.Background(), 7)
+
+	if err := exporters.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("failed to flush exporters: %v", err)
+	}
+
+	requests := receiver.getRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 remote-write request, got %d", len(requests))
+	}
+
+	request := requests[0]
+
+	if request.Path != "/api/v1/push" {
+		t.Errorf("expected path /api/v1/push, got %s", request.Path)
+	}
+
+	expectedHeaders := map[string]string{
+		"Content-Encoding":                  "snappy",
+		"Content-Type":                      "application/x-protobuf",
+		"X-Prometheus-Remote-Write-Version": "0.1.0",
+		"X-Scope-OrgID":                     "tenant-1",
+	}
+
+	for key, expected := range expectedHeaders {
+		if value := request.Header.Get(key); value != expected {
+			t.Errorf("expected header %s to be %s, got %s", key, expected, value)
+		}
+	}
+
+	targetLabels := map[string]string{
+		"job":             "batch/remote-write-test",
+		"instance":        "worker-1",
+		"otel_scope_name": "remote-write-test",
+	}
+
+	expectedValues := []struct {
+		Name   string
+		Labels map[string]string
+		Value  float64
+	}{
+		{Name: "remote_write_test_requests_total", Labels: map[string]string{"http_method": "GET"}, Value: 2},
+		{Name: "remote_write_test_duration_seconds_bucket", Labels: map[string]string{"le": "0"}, Value: 0},
+		{Name: "remote_write_test_duration_seconds_bucket", Labels: map[string]string{"le": "5"}, Value: 1},
+		{Name: "remote_write_test_duration_seconds_bucket", Labels: map[string]string{"le": "10"}, Value: 2},
+		{Name: "remote_write_test_duration_seconds_bucket", Labels: map[string]string{"le": "+Inf"}, Value: 2},
+		{Name: "remote_write_test_duration_seconds_sum", Value: 8.5},
+		{Name: "remote_write_test_duration_seconds_count", Value: 2},
+	}
+
+	for _, expected := range expectedValues {
+		labels := maps.Clone(targetLabels)
+		maps.Copy(labels, expected.Labels)
+
+		value, ok := request.getValue(expected.Name, labels)
+		if !ok {
+			t.Errorf("expected series %s%v, got: %v", expected.Name, labels, request.Series)
+
+			continue
+		}
+
+		if value != expected.Value {
+			t.Errorf("expected %s%v to be %v, got %v", expected.Name, expected.Labels, expected.Value, value)
+		}
+	}
+
+	expectedMetadata := map[string]uint64{
+		"remote_write_test_requests_total":   remoteWriteMetricTypeCounter,
+		"remote_write_test_duration_seconds": remoteWriteMetricTypeHistogram,
+	}
+
+	for name, expected := range expectedMetadata {
+		if request.Metadata[name] != expected {
+			t.Errorf("expected metadata type of %s to be %d, got %d", name, expected, request.Metadata[name])
+		}
+	}
+}
+
+func TestNewRemoteWriteExporter_Endpoint(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        OTLPConfig
+		Expected      string
+		ExpectedError error
+	}{
+		{
+			Name:     "uses the metrics endpoint as the full URL",
+			Config:   OTLPConfig{OtlpMetricsEndpoint: "mimir:8080/api/v1/push"},
+			Expected: "https://mimir:8080/api/v1/push",
+		},
+		{
+			Name:     "appends the default path to the general endpoint",
+			Config:   OTLPConfig{OtlpEndpoint: "http://prometheus:9090"},
+			Expected: "http://prometheus:9090/api/v1/write",
+		},
+		{
+			Name:     "joins the default path to the general endpoint with a trailing slash",
+			Config:   OTLPConfig{OtlpEndpoint: "http://prometheus:9090/"},
+			Expected: "http://prometheus:9090/api/v1/write",
+		},
+		{
+			Name:     "joins the default path to the general endpoint without scheme",
+			Config:   OTLPConfig{OtlpEndpoint: "prometheus:9090/prefix"},
+			Expected: "https://prometheus:9090/prefix/api/v1/write",
+		},
+		{
+			Name: "disables TLS of insecure endpoints without scheme",
+			Config: OTLPConfig{
+				OtlpMetricsEndpoint: "mimir:8080/api/v1/push",
+				OtlpMetricsInsecure: boolPtr(true),
+			},
+			Expected: "http://mimir:8080/api/v1/push",
+		},
+		{
+			Name: "disables TLS of the general insecure endpoint without scheme",
+			Config: OTLPConfig{
+				OtlpEndpoint: "prometheus:9090",
+				OtlpInsecure: boolPtr(true),
+			},
+			Expected: "http://prometheus:9090/api/v1/write",
+		},
+		{
+			Name: "keeps the explicit https scheme of insecure endpoints",
+			Config: OTLPConfig{
+				OtlpMetricsEndpoint: "https://mimir:8080/api/v1/push",
+				OtlpMetricsInsecure: boolPtr(true),
+			},
+			Expected: "https://mimir:8080/api/v1/push",
+		},
+		{
+			Name:          "requires an endpoint",
+			Config:        OTLPConfig{},
+			ExpectedError: ErrMetricsOTLPEndpointRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			exporter, err := newRemoteWriteExporter(&tc.Config)
+			if tc.ExpectedError != nil {
+				if !errors.Is(err, tc.ExpectedError) {
+					t.Fatalf("expected error %v, got: %v", tc.ExpectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if exporter.endpoint != tc.Expected {
+				t.Errorf("expected endpoint %s, got %s", tc.Expected, exporter.endpoint)
+			}
+		})
+	}
+}
+
+func TestRemoteWriteExporter_Export(t *testing.T) {
+	gauge := &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{
+			{
+				Metrics: []metricdata.Metrics{
+					{
+						Name: "queue.size",
+						Data: metricdata.Gauge[int64]{
+							DataPoints: []metricdata.DataPoint[int64]{
+								{Time: time.UnixMilli(1700000000000), Value: 3},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("returns the error of rejected requests", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "out of order sample", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		exporter, err := newRemoteWriteExporter(&OTLPConfig{OtlpMetricsEndpoint: server.URL})
+		if err != nil {
+			t.Fatalf("failed to create exporter: %v", err)
+		}
+
+		err = exporter.Export(context.Background(), gauge)
+		if !errors.Is(err, errRemoteWriteFailed) {
+			t.Errorf("expected error %v, got: %v", errRemoteWriteFailed, err)
+		}
+	})
+
+	t.Run("encodes samples with millisecond timestamps", func(t *testing.T) {
+		receiver := newTestRemoteWriteReceiver(t)
+
+		exporter, err := newRemoteWriteExporter(&OTLPConfig{
+			OtlpMetricsEndpoint:        receiver.URL,
+			PrometheusWithoutScopeInfo: boolPtr(true),
+		})
+		if err != nil {
+			t.Fatalf("failed to create exporter: %v", err)
+		}
+
+		if err := exporter.Export(context.Background(), gauge); err != nil {
+			t.Fatalf("failed to export metrics: %v", err)
+		}
+
+		requests := receiver.getRequests()
+		if len(requests) != 1 || len(requests[0].Series) != 1 {
+			t.Fatalf("expected 1 request with 1 series, got %v", requests)
+		}
+
+		series := requests[0].Series[0]
+		expectedLabels := map[string]string{prometheusMetricNameLabel: "queue_size"}
+
+		if len(series.Labels) != len(expectedLabels) || series.Labels[prometheusMetricNameLabel] != "queue_size" {
+			t.Errorf("expected labels %v, got %v", expectedLabels, series.Labels)
+		}
+
+		if series.Value != 3 || series.Timestamp != 1700000000000 {
+			t.Errorf("expected sample 3 at 1700000000000, got %v at %d", series.Value, series.Timestamp)
+		}
+	})
+
+	t.Run("sends the metadata of metric families in multiple scopes once", func(t *testing.T) {
+		receiver := newTestRemoteWriteReceiver(t)
+
+		exporter, err := newRemoteWriteExporter(&OTLPConfig{OtlpMetricsEndpoint: receiver.URL})
+		if err != nil {
+			t.Fatalf("failed to create exporter: %v", err)
+		}
+
+		scopeMetrics := gauge.ScopeMetrics[0]
+		rm := &metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{
+				{Scope: instrumentation.Scope{Name: "worker"}, Metrics: scopeMetrics.Metrics},
+				{Scope: instrumentation.Scope{Name: "scheduler"}, Metrics: scopeMetrics.Metrics},
+			},
+		}
+
+		if err := exporter.Export(context.Background(), rm); err != nil {
+			t.Fatalf("failed to export metrics: %v", err)
+		}
+
+		requests := receiver.getRequests()
+		if len(requests) != 1 || len(requests[0].Series) != 2 {
+			t.Fatalf("expected 1 request with 2 series, got %v", requests)
+		}
+
+		expectedMetadata := map[string]uint64{"queue_size": remoteWriteMetricTypeGauge}
+		if !maps.Equal(requests[0].Metadata, expectedMetadata) {
+			t.Errorf("expected metadata %v, got %v", expectedMetadata, requests[0].Metadata)
+		}
+	})
+
+	t.Run("keeps data-point labels that conflict with target labels with the exported prefix", func(t *testing.T) {
+		receiver := newTestRemoteWriteReceiver(t)
+
+		exporter, err := newRemoteWriteExporter(&OTLPConfig{OtlpMetricsEndpoint: receiver.URL})
+		if err != nil {
+			t.Fatalf("failed to create exporter: %v", err)
+		}
+
+		rm := &metricdata.ResourceMetrics{
+			Resource: resource.NewSchemaless(attribute.String("service.name", "api")),
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Scope: instrumentation.Scope{Name: "worker"},
+				Metrics: []metricdata.Metrics{{
+					Name: "queue.size",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{
+							Attributes: attribute.NewSet(
+								attribute.String("job", "batch"),
+								attribute.String("otel_scope_name", "custom"),
+							),
+							Value: 3,
+						}},
+					},
+				}},
+			}},
+		}
+
+		if err := exporter.Export(context.Background(), rm); err != nil {
+			t.Fatalf("failed to export metrics: %v", err)
+		}
+
+		requests := receiver.getRequests()
+		if len(requests) != 1 || len(requests[0].Series) != 1 {
+			t.Fatalf("expected 1 request with 1 series, got %v", requests)
+		}
+
+		expectedLabels := map[string]string{
+			prometheusMetricNameLabel:  "queue_size",
+			"job":                      "api",
+			"exported_job":             "batch",
+			"otel_scope_name":          "worker",
+			"exported_otel_scope_name": "custom",
+		}
+
+		if labels := requests[0].Series[0].Labels; !maps.Equal(labels, expectedLabels) {
+			t.Errorf("expected labels %v, got %v", expectedLabels, labels)
+		}
+	})
+
+	t.Run("rejects exports after shutdown", func(t *testing.T) {
+		exporter, err := newRemoteWriteExporter(&OTLPConfig{OtlpMetricsEndpoint: "http://localhost:9090"})
+		if err != nil {
+			t.Fatalf("failed to create exporter: %v", err)
+		}
+
+		if err := exporter.Shutdown(context.Background()); err != nil {
+			t.Fatalf("failed to shutdown exporter: %v", err)
+		}
+
+		err = exporter.Export(context.Background(), gauge)
+		if !errors.Is(err, errRemoteWriteExporterShutdown) {
+			t.Errorf("expected error %v, got: %v", errRemoteWriteExporterShutdown, err)
+		}
+	})
+}
+
+// testRemoteWriteProto declares the messages of the prometheus/prompb package
+// that are used by the remote-write 1.0 protocol, without exemplars and native histograms.
+const testRemoteWriteProto = `
+name: "prometheus/remote.proto"
+package: "prometheus"
+syntax: "proto3"
+message_type: {
+	name: "WriteRequest"
+	field: {name: "timeseries" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".prometheus.TimeSeries"}
+	field: {name: "metadata" number: 3 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".prometheus.MetricMetadata"}
+}
+message_type: {
+	name: "MetricMetadata"
+	field: {name: "type" number: 1 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".prometheus.MetricMetadata.MetricType"}
+	field: {name: "metric_family_name" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "help" number: 4 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "unit" number: 5 label: LABEL_OPTIONAL type: TYPE_STRING}
+	enum_type: {
+		name: "MetricType"
+		value: {name: "UNKNOWN" number: 0}
+		value: {name: "COUNTER" number: 1}
+		value: {name: "GAUGE" number: 2}
+		value: {name: "HISTOGRAM" number: 3}
+		value: {name: "GAUGEHISTOGRAM" number: 4}
+		value: {name: "SUMMARY" number: 5}
+		value: {name: "INFO" number: 6}
+		value: {name: "STATESET" number: 7}
+	}
+}
+message_type: {
+	name: "Sample"
+	field: {name: "value" number: 1 label: LABEL_OPTIONAL type: TYPE_DOUBLE}
+	field: {name: "timestamp" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64}
+}
+message_type: {
+	name: "TimeSeries"
+	field: {name: "labels" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".prometheus.Label"}
+	field: {name: "samples" number: 2 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".prometheus.Sample"}
+}
+message_type: {
+	name: "Label"
+	field: {name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING}
+}
+`
+
+// returns the fields of the message and fails if the message contains fields that are not in the schema.
+func getTestProtoFields(t *testing.T, message protoreflect.Message) func(name string) protoreflect.Value {
+	t.Helper()
+
+	if unknown := message.GetUnknown(); len(unknown) > 0 {
+		t.Errorf("unexpected unknown fields of %s: %v", message.Descriptor().FullName(), unknown)
+	}
+
+	fields := message.Descriptor().Fields()
+
+	return func(name string) protoreflect.Value {
+		return message.Get(fields.ByName(protoreflect.Name(name)))
+	}
+}
+
+// decode the prometheus.WriteRequest message with the schema of the remote-write protocol.
+func decodeTestRemoteWriteRequest(t *testing.T, payload []byte) testRemoteWriteRequest {
+	t.Helper()
+
+	fileProto := &descriptorpb.FileDescriptorProto{}
+	if err := prototext.Unmarshal([]byte(testRemoteWriteProto), fileProto); err != nil {
+		t.Fatalf("failed to parse the remote-write schema: %v", err)
+	}
+
+	file, err := protodesc.NewFile(fileProto, nil)
+	if err != nil {
+		t.Fatalf("failed to build the remote-write schema: %v", err)
+	}
+
+	message := dynamicpb.NewMessage(file.Messages().ByName("WriteRequest"))
+	if err := proto.Unmarshal(payload, message); err != nil {
+		t.Fatalf("failed to decode the remote-write request: %v", err)
+	}
+
+	request := testRemoteWriteRequest{Metadata: map[string]uint64{}}
+	requestFields := getTestProtoFields(t, message)
+
+	timeseries := requestFields("timeseries").List()
+	for i := range timeseries.Len() {
+		seriesFields := getTestProtoFields(t, timeseries.Get(i).Message())
+		series := testRemoteWriteSeries{Labels: map[string]string{}}
+
+		labels := seriesFields("labels").List()
+		for j := range labels.Len() {
+			labelFields := getTestProtoFields(t, labels.Get(j).Message())
+			series.Labels[labelFields("name").String()] = labelFields("value").String()
+		}
+
+		samples := seriesFields("samples").List()
+		if samples.Len() != 1 {
+			t.Errorf("expected 1 sample of the series %v, got %d", series.Labels, samples.Len())
+		}
+
+		for j := range samples.Len() {
+			sampleFields := getTestProtoFields(t, samples.Get(j).Message())
+			series.Value = sampleFields("value").Float()
+			series.Timestamp = sampleFields("timestamp").Int()
+		}
+
+		request.Series = append(request.Series, series)
+	}
+
+	metadata := requestFields("metadata").List()
+	for i := range metadata.Len() {
+		metadataFields := getTestProtoFields(t, metadata.Get(i).Message())
+		name := metadataFields("metric_family_name").String()
+
+		if _, ok := request.Metadata[name]; ok {
+			t.Errorf("expected 1 metadata entry of the metric family %s", name)
+		}
+
+		request.Metadata[name] = uint64(metadataFields("type").Enum()) //nolint:gosec
+	}
+
+	return request
+}
@@ -0,0 +1,300 @@
+package gotel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	traceapi "go.opentelemetry.io/otel/trace"
+)
+
+// Helper function to find a free TCP port
+func getFreePort(t *testing.T) uint {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+
+	return uint(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func TestSetupOTelExporters_PrometheusServer(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	t.Run("serves metrics on the configured port", func(t *testing.T) {
+		port := getFreePort(t)
+		config := &OTLPConfig{
+			ServiceName:     "prometheus-server-test",
+			MetricsExporter: OTELMetricsExporterPrometheus,
+			PrometheusPort:  &port,
+		}
+
+		exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+
+		counter, err := exporters.Meter.Int64Counter("prometheus_server_test_requests")
+		if err != nil {
+			t.Fatalf("failed to create counter: %v", err)
+		}
+
+		counter.Add(context.Background(), 1)
+
+		metricsURL := fmt.Sprintf("http://127.0.0.1:%d/metrics", port)
+
+		resp, err := http.Get(metricsURL)
+		if err != nil {
+			t.Fatalf("failed to scrape metrics: %v", err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("failed to read response body: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status 200, got %d", resp.StatusCode)
+		}
+
+		if !strings.Contains(string(body), "prometheus_server_test_requests_total") {
+			t.Errorf("expected metrics to contain the test counter, got: %s", body)
+		}
+
+		if err := exporters.Shutdown(context.Background()); err != nil {
+			t.Fatalf("failed to shutdown exporters: %v", err)
+		}
+
+		if resp, err := http.Get(metricsURL); err == nil {
+			resp.Body.Close()
+			t.Error("expected the Prometheus server to be stopped after shutdown")
+		}
+	})
+
+	t.Run("exposes handler when port is unset", func(t *testing.T) {
+		config := &OTLPConfig{
+			ServiceName:     "prometheus-handler-test",
+			MetricsExporter: OTELMetricsExporterPrometheus,
+		}
+
+		exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		if exporters.PrometheusHandler == nil {
+			t.Fatal("expected non-nil PrometheusHandler")
+		}
+
+		recorder := httptest.NewRecorder()
+		exporters.PrometheusHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("exposes Go runtime metrics once", func(t *testing.T) {
+		config := &OTLPConfig{
+			ServiceName:     "prometheus-runtime-test",
+			MetricsExporter: OTELMetricsExporterPrometheus,
+		}
+
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			config,
+			"v1.0.0",
+			logger,
+			WithGlobalRegistration(false),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		recorder := httptest.NewRecorder()
+		exporters.PrometheusHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		body := recorder.Body.String()
+
+		if !strings.Contains(body, "go_goroutine_count") {
+			t.Errorf("expected the OpenTelemetry Go runtime metrics, got: %s", body)
+		}
+
+		// metrics of the Go collector of the Prometheus client.
+		for _, unexpected := range []string{"go_goroutines", "go_memstats_"} {
+			if strings.Contains(body, unexpected) {
+				t.Errorf("expected no %s, got: %s", unexpected, body)
+			}
+		}
+
+		// the process collector only reports metrics on platforms that it supports.
+		if runtime.GOOS == "linux" && !strings.Contains(body, "process_cpu_seconds_total") {
+			t.Errorf("expected the metrics of the process collector, got: %s", body)
+		}
+	})
+
+	t.Run("disables the process collector with Go metrics", func(t *testing.T) {
+		registry := newPrometheusRegistry(&OTLPConfig{
+			MetricsExporter:  OTELMetricsExporterPrometheus,
+			DisableGoMetrics: boolPtr(true),
+		})
+
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("failed to gather metrics: %v", err)
+		}
+
+		if len(families) != 0 {
+			t.Errorf("expected no metrics of an empty registry, got %d families", len(families))
+		}
+	})
+
+	t.Run("exposes exemplars in the OpenMetrics format", func(t *testing.T) {
+		config := &OTLPConfig{
+			ServiceName:     "prometheus-exemplar-test",
+			MetricsExporter: OTELMetricsExporterPrometheus,
+		}
+
+		exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		histogram, err := exporters.Meter.Float64Histogram("prometheus_exemplar_test_duration")
+		if err != nil {
+			t.Fatalf("failed to create histogram: %v", err)
+		}
+
+		// the trace-based filter samples measurements in the context of a sampled span.
+		ctx := traceapi.ContextWithSpanContext(context.Background(), traceapi.NewSpanContext(traceapi.SpanContextConfig{
+			TraceID:    traceapi.TraceID{0x01, 0x02, 0x03},
+			SpanID:     traceapi.SpanID{0x04, 0x05, 0x06},
+			TraceFlags: traceapi.FlagsSampled,
+		}))
+		histogram.Record(ctx, 1.5)
+
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		request.Header.Set("Accept", "application/openmetrics-text")
+
+		recorder := httptest.NewRecorder()
+		exporters.PrometheusHandler.ServeHTTP(recorder, request)
+
+		if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(
+			contentType,
+			"application/openmetrics-text",
+		) {
+			t.Errorf("expected the OpenMetrics content type, got %s", contentType)
+		}
+
+		if !strings.Contains(recorder.Body.String(), `trace_id="01020300000000000000000000000000"`) {
+			t.Errorf("expected the exemplar of the trace, got: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("runs multiple exporters with dedicated registries", func(t *testing.T) {
+		bodies := make([]string, 2)
+
+		for i, namespace := range []string{"first", "second"} {
+			config := &OTLPConfig{
+				ServiceName:                 "prometheus-registry-test",
+				MetricsExporter:             OTELMetricsExporterPrometheus,
+				PrometheusNamespace:         namespace,
+				PrometheusWithoutScopeInfo:  boolPtr(true),
+				PrometheusWithoutTargetInfo: boolPtr(true),
+				DisableGoMetrics:            boolPtr(true),
+			}
+
+			exporters, err := SetupOTelExporters(
+				context.Background(),
+				config,
+				"v1.0.0",
+				logger,
+				WithGlobalRegistration(false),
+			)
+			if err != nil {
+				t.Fatalf("failed to setup exporters %d: %v", i, err)
+			}
+			defer exporters.Shutdown(context.Background())
+
+			if exporters.PrometheusRegistry == nil {
+				t.Fatal("expected non-nil PrometheusRegistry")
+			}
+
+			counter, err := exporters.Meter.Int64Counter("registry.requests")
+			if err != nil {
+				t.Fatalf("failed to create counter: %v", err)
+			}
+
+			counter.Add(context.Background(), 1)
+
+			recorder := httptest.NewRecorder()
+			exporters.PrometheusHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			bodies[i] = recorder.Body.String()
+		}
+
+		for i, namespace := range []string{"first", "second"} {
+			if !strings.Contains(bodies[i], namespace+"_registry_requests_total 1") {
+				t.Errorf("expected the %s namespace, got: %s", namespace, bodies[i])
+			}
+
+			for _, unexpected := range []string{"otel_scope_name", "target_info", "go_goroutines"} {
+				if strings.Contains(bodies[i], unexpected) {
+					t.Errorf("expected no %s, got: %s", unexpected, bodies[i])
+				}
+			}
+		}
+
+		if strings.Contains(bodies[0], "second_") || strings.Contains(bodies[1], "first_") {
+			t.Errorf("expected isolated registries, got: %s\n%s", bodies[0], bodies[1])
+		}
+	})
+
+	t.Run("returns error when port is in use", func(t *testing.T) {
+		listener, err := net.Listen("tcp", ":0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		defer listener.Close()
+
+		port := uint(listener.Addr().(*net.TCPAddr).Port)
+		config := &OTLPConfig{
+			ServiceName:     "prometheus-conflict-test",
+			MetricsExporter: OTELMetricsExporterPrometheus,
+			PrometheusPort:  &port,
+		}
+
+		_, err = SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+		if err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+
+	t.Run("does not expose handler for other exporters", func(t *testing.T) {
+		config := &OTLPConfig{
+			ServiceName: "no-prometheus-test",
+		}
+
+		exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		if exporters.PrometheusHandler != nil {
+			t.Error("expected nil PrometheusHandler")
+		}
+	})
+}
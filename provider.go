@@ -3,120 +3,326 @@ package gotel
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/collectors"
+	otelRuntime "go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
-	otelPrometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	logapi "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/global"
 	metricapi "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk"
+	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
 	traceapi "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	otlpDefaultHTTPPort = "4318"
+	// name of the instrumentation scope of metrics that are produced by this library.
+	instrumentationScopeName = "github.com/hasura/gotel"
 )
 
 // OTelExporters contains outputs of OpenTelemetry exporters.
 type OTelExporters struct {
-	Tracer   *Tracer
-	Meter    metricapi.Meter
-	Logger   *slog.Logger
-	Shutdown func(context.Context) error
+	Tracer *Tracer
+	Meter  metricapi.Meter
+	Logger *slog.Logger
+	// PrometheusHandler serves metrics in the Prometheus text format if the metrics exporter is prometheus.
+	// Mount it on your own mux when the Prometheus port is not configured.
+	PrometheusHandler http.Handler
+	// PrometheusRegistry is the dedicated registry of the Prometheus exporter
+	// if the metrics exporter is prometheus or pushgateway.
+	// Register additional collectors to serve or push them with the metrics of the pipeline.
+	PrometheusRegistry *prometheus.Registry
+	// TracerProvider, MeterProvider and LoggerProvider are the SDK providers of this pipeline.
+	// Use them to create additional named tracers, meters and loggers with the same resource,
+	// or to create instruments when the providers are not registered globally.
+	TracerProvider *trace.TracerProvider
+	MeterProvider  *metric.MeterProvider
+	LoggerProvider *log.LoggerProvider
+	// Propagator injects and extracts the trace context of this pipeline.
+	Propagator propagation.TextMapPropagator
+	Shutdown   func(context.Context) error
+
+	restoreGlobals   func()
+	prometheusServer *http.Server
+	pushgateway      *pushgatewayExporter
+}
+
+// returns the propagator of the pipeline, or the global propagator if it is not set.
+func (oe *OTelExporters) getPropagator() propagation.TextMapPropagator {
+	if oe.Propagator != nil {
+		return oe.Propagator
+	}
+
+	return otel.GetTextMapPropagator()
+}
+
+// ForceFlush exports all pending spans, metrics and log records of the pipeline without shutting down.
+// Call it before short-lived commands or serverless handlers return.
+func (oe *OTelExporters) ForceFlush(ctx context.Context) error {
+	errorMsgs := []error{}
+
+	if oe.TracerProvider != nil {
+		err := oe.TracerProvider.ForceFlush(ctx)
+		if err != nil {
+			errorMsgs = append(errorMsgs, err)
+		}
+	}
+
+	if oe.MeterProvider != nil {
+		err := oe.MeterProvider.ForceFlush(ctx)
+		if err != nil {
+			errorMsgs = append(errorMsgs, err)
+		}
+	}
+
+	if oe.pushgateway != nil {
+		err := oe.pushgateway.push(ctx)
+		if err != nil {
+			errorMsgs = append(errorMsgs, err)
+		}
+	}
+
+	if oe.LoggerProvider != nil {
+		err := oe.LoggerProvider.ForceFlush(ctx)
+		if err != nil {
+			errorMsgs = append(errorMsgs, err)
+		}
+	}
+
+	return errors.Join(errorMsgs...)
 }
 
 // SetupOTelExporters set up OpenTelemetry exporters from configuration.
+// Custom processors, readers, exporters and resources can be injected with [SetupOption]s.
+// They are merged with the ones produced by the configuration.
+//
+// The providers, propagator and error handler logger are registered globally by default
+// and the previous global providers and propagator are restored on Shutdown.
+// Use [WithGlobalRegistration] to run isolated pipelines in the same process.
 func SetupOTelExporters(
 	ctx context.Context,
 	config *OTLPConfig,
 	serviceVersion string,
 	logger *slog.Logger,
+	options ...SetupOption,
 ) (*OTelExporters, error) {
-	otel.SetLogger(logr.FromSlogHandler(logger.Handler()))
-
-	otelDisabled := os.Getenv("OTEL_SDK_DISABLED") == "true"
+	otelDisabled := (config.SdkDisabled != nil && *config.SdkDisabled) || os.Getenv("OTEL_SDK_DISABLED") == "true"
+	setupOpts := newSetupOptions(options)
 
 	// Set up resource.
-	res := newResource(config.ServiceName, serviceVersion)
-
-	traceProvider, err := setupOTelTraceProvider(ctx, config, res, otelDisabled)
+	baseResource, err := newResource(config, serviceVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	otel.SetTracerProvider(traceProvider)
+	res, err := mergeResources(baseResource, setupOpts.resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge resources: %w", err)
+	}
 
-	meterProvider, err := setupOTelMetricsProvider(ctx, config, res, otelDisabled)
+	// Set up propagator. The trace context is propagated even if traces are not exported
+	// so that downstream services can continue the trace.
+	prop, err := newPropagator(config.GetPropagators())
 	if err != nil {
 		return nil, err
 	}
 
-	// configure metrics exporter
-	loggerProvider, err := newLoggerProvider(ctx, config, otelDisabled, res)
+	traceProvider, err := setupOTelTraceProvider(ctx, config, res, otelDisabled, setupOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	global.SetLoggerProvider(loggerProvider)
+	prometheusRegistry := newPrometheusRegistry(config)
+
+	meterProvider, err := setupOTelMetricsProvider(ctx, config, res, otelDisabled, prometheusRegistry, setupOpts)
+	if err != nil {
+		return nil, errors.Join(err, traceProvider.Shutdown(ctx))
+	}
+
+	// configure metrics exporter
+	loggerProvider, err := newLoggerProvider(ctx, config, otelDisabled, res, setupOpts)
+	if err != nil {
+		return nil, errors.Join(err, traceProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}
+
+	state := &OTelExporters{
+		Tracer: &Tracer{
+			traceProvider.Tracer(config.ServiceName, traceapi.WithSchemaURL(semconv.SchemaURL)),
+		},
+		Meter: meterProvider.Meter(
+			config.ServiceName,
+			metricapi.WithSchemaURL(semconv.SchemaURL),
+		),
+		Logger:             slog.New(createLogHandler(config.ServiceName, logger, loggerProvider)),
+		TracerProvider:     traceProvider,
+		MeterProvider:      meterProvider,
+		LoggerProvider:     loggerProvider,
+		Propagator:         prop,
+		PrometheusRegistry: prometheusRegistry,
+	}
+
+	if setupOpts.globalRegistration {
+		state.restoreGlobals = registerOTelGlobals(state, logger)
+	}
+
+	state.Shutdown = state.shutdown
+
+	err = state.startPrometheusExporters(config, otelDisabled, logger)
+	if err != nil {
+		return nil, errors.Join(err, state.Shutdown(ctx))
+	}
+
+	return state, nil
+}
+
+// start the Prometheus server or the Pushgateway exporter of the Prometheus registry.
+func (oe *OTelExporters) startPrometheusExporters(config *OTLPConfig, otelDisabled bool, logger *slog.Logger) error {
+	switch config.GetMetricsExporter() {
+	case OTELMetricsExporterPrometheus:
+		oe.PrometheusHandler = newPrometheusHandler(oe.PrometheusRegistry)
 
-	shutdownFunc := func(ctx context.Context) error {
-		errorMsgs := []error{}
+		if config.PrometheusPort == nil {
+			return nil
+		}
 
-		err := traceProvider.Shutdown(ctx)
+		server, err := startPrometheusServer(*config.PrometheusPort, oe.PrometheusHandler, logger)
 		if err != nil {
-			errorMsgs = append(errorMsgs, err)
+			return fmt.Errorf("failed to start the Prometheus server: %w", err)
 		}
 
-		meterErr := meterProvider.Shutdown(ctx)
-		if meterErr != nil {
-			errorMsgs = append(errorMsgs, meterErr)
+		oe.prometheusServer = server
+	case OTELMetricsExporterPushgateway:
+		if otelDisabled {
+			return nil
 		}
 
-		loggerErr := loggerProvider.Shutdown(ctx)
-		if loggerErr != nil {
-			errorMsgs = append(errorMsgs, loggerErr)
+		pushgateway, err := newPushgatewayExporter(config, oe.PrometheusRegistry, logger)
+		if err != nil {
+			return err
 		}
 
-		if len(errorMsgs) > 0 {
-			return errors.Join(errorMsgs...)
+		pushgateway.start()
+		oe.pushgateway = pushgateway
+	default:
+	}
+
+	return nil
+}
+
+// shutdown restores the previous global providers, then stops servers and providers of the pipeline.
+func (oe *OTelExporters) shutdown(ctx context.Context) error {
+	if oe.restoreGlobals != nil {
+		oe.restoreGlobals()
+	}
+
+	errorMsgs := []error{}
+
+	if oe.prometheusServer != nil {
+		serverErr := oe.prometheusServer.Shutdown(ctx)
+		if serverErr != nil {
+			errorMsgs = append(errorMsgs, serverErr)
 		}
+	}
 
-		return nil
+	err := oe.TracerProvider.Shutdown(ctx)
+	if err != nil {
+		errorMsgs = append(errorMsgs, err)
 	}
 
-	otelLogger := slog.New(createLogHandler(config.ServiceName, logger, loggerProvider))
-	state := &OTelExporters{
-		Tracer: &Tracer{
-			traceProvider.Tracer(config.ServiceName, traceapi.WithSchemaURL(semconv.SchemaURL)),
-		},
-		Meter: meterProvider.Meter(
-			config.ServiceName,
-			metricapi.WithSchemaURL(semconv.SchemaURL),
-		),
-		Logger:   otelLogger,
-		Shutdown: shutdownFunc,
+	// push the last metrics before the meter provider is shut down.
+	if oe.pushgateway != nil {
+		pushErr := oe.pushgateway.Shutdown(ctx)
+		if pushErr != nil {
+			errorMsgs = append(errorMsgs, pushErr)
+		}
+	}
+
+	meterErr := oe.MeterProvider.Shutdown(ctx)
+	if meterErr != nil {
+		errorMsgs = append(errorMsgs, meterErr)
+	}
+
+	loggerErr := oe.LoggerProvider.Shutdown(ctx)
+	if loggerErr != nil {
+		errorMsgs = append(errorMsgs, loggerErr)
+	}
+
+	if len(errorMsgs) > 0 {
+		return errors.Join(errorMsgs...)
 	}
 
-	return state, err
+	return nil
+}
+
+type globalTextMapPropagator struct {
+	propagation.TextMapPropagator
+}
+
+// register the providers and propagator of exporters globally.
+// Returns a function that restores the previous global providers and propagator
+// if they have not been replaced since. The global error handler logger cannot be restored.
+func registerOTelGlobals(exporters *OTelExporters, logger *slog.Logger) func() {
+	previousTracerProvider := otel.GetTracerProvider()
+	previousMeterProvider := otel.GetMeterProvider()
+	previousLoggerProvider := global.GetLoggerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+
+	otel.SetLogger(logr.FromSlogHandler(logger.Handler()))
+	// wrap the propagator in a pointer because composite propagators are not comparable.
+	propagator := &globalTextMapPropagator{exporters.Propagator}
+
+	otel.SetTextMapPropagator(propagator)
+	otel.SetTracerProvider(exporters.TracerProvider)
+	otel.SetMeterProvider(exporters.MeterProvider)
+	global.SetLoggerProvider(exporters.LoggerProvider)
+
+	return func() {
+		if otel.GetTracerProvider() == traceapi.TracerProvider(exporters.TracerProvider) {
+			otel.SetTracerProvider(previousTracerProvider)
+		}
+
+		if otel.GetMeterProvider() == metricapi.MeterProvider(exporters.MeterProvider) {
+			otel.SetMeterProvider(previousMeterProvider)
+		}
+
+		if global.GetLoggerProvider() == logapi.LoggerProvider(exporters.LoggerProvider) {
+			global.SetLoggerProvider(previousLoggerProvider)
+		}
+
+		if otel.GetTextMapPropagator() == propagation.TextMapPropagator(propagator) {
+			otel.SetTextMapPropagator(previousPropagator)
+		}
+	}
 }
 
 func setupOTelTraceProvider(
@@ -124,14 +330,78 @@ func setupOTelTraceProvider(
 	config *OTLPConfig,
 	resources *resource.Resource,
 	otelDisabled bool,
+	setupOpts *setupOptions,
 ) (*trace.TracerProvider, error) {
+	sampler, err := newTraceSampler(config)
+	if err != nil {
+		return nil, err
+	}
+
+	providerOptions := []trace.TracerProviderOption{
+		trace.WithResource(resources),
+		trace.WithSampler(sampler),
+	}
+
+	if setupOpts.idGenerator != nil {
+		providerOptions = append(providerOptions, trace.WithIDGenerator(setupOpts.idGenerator))
+	}
+
+	for _, processor := range setupOpts.spanProcessors {
+		providerOptions = append(providerOptions, trace.WithSpanProcessor(processor))
+	}
+
+	// create the configured exporter first so that no batch processor is started if it fails.
+	traceExporter, err := newTraceExporter(ctx, config, otelDisabled)
+	if err != nil {
+		return nil, err
+	}
+
+	spanExporters := setupOpts.spanExporters
+	if traceExporter != nil {
+		spanExporters = append(slices.Clone(spanExporters), traceExporter)
+	}
+
+	for _, exporter := range spanExporters {
+		providerOptions = append(
+			providerOptions,
+			trace.WithBatcher(exporter, newBatchSpanProcessorOptions(config)...),
+		)
+	}
+
+	return trace.NewTracerProvider(providerOptions...), nil
+}
+
+// create the span exporter of the configured traces exporter.
+// Returns nil if the exporter is disabled or the OTLP traces endpoint is empty.
+func newTraceExporter(ctx context.Context, config *OTLPConfig, otelDisabled bool) (trace.SpanExporter, error) {
+	if otelDisabled {
+		return nil, nil
+	}
+
+	tracesExporterType := config.GetTracesExporter()
+
+	switch tracesExporterType {
+	case OTELTracesExporterConsole:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case OTELTracesExporterFile:
+		return newFileTraceExporter(ctx, config.GetTracesFilePath())
+	case OTELTracesExporterOTLP:
+		return setupTraceExporterOTLP(ctx, config)
+	case OTELTracesExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOTELTracesExporterType, tracesExporterType)
+	}
+}
+
+func setupTraceExporterOTLP(ctx context.Context, config *OTLPConfig) (trace.SpanExporter, error) {
 	tracesEndpoint := config.OtlpTracesEndpoint
 	if tracesEndpoint == "" && config.OtlpEndpoint != "" {
 		tracesEndpoint = config.OtlpEndpoint + "/v1/traces"
 	}
 
-	if otelDisabled || tracesEndpoint == "" {
-		return trace.NewTracerProvider(trace.WithResource(resources)), nil
+	if tracesEndpoint == "" {
+		return nil, nil
 	}
 
 	endpoint, protocol, insecure, err := parseOTLPEndpoint(
@@ -150,51 +420,46 @@ func setupOTelTraceProvider(
 		return nil, fmt.Errorf("failed to parse OTLP traces compression: %w", err)
 	}
 
-	// Set up propagator.
-	prop := newPropagator()
-	otel.SetTextMapPropagator(prop)
-
-	var traceExporter *otlptrace.Exporter
+	tlsConfig, err := newOTLPTLSConfig(
+		getDefault(config.OtlpTracesCertificate, config.OtlpCertificate),
+		getDefault(config.OtlpTracesClientCertificate, config.OtlpClientCertificate),
+		getDefault(config.OtlpTracesClientKey, config.OtlpClientKey),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OTLP traces TLS certificates: %w", err)
+	}
 
 	if protocol == OTLPProtocolGRPC {
 		options := []otlptracegrpc.Option{
 			otlptracegrpc.WithEndpoint(endpoint),
 			otlptracegrpc.WithCompressor(string(compressorStr)),
+			otlptracegrpc.WithHeaders(config.GetOTLPTracesHeaders()),
+			otlptracegrpc.WithTimeout(config.GetOTLPTracesTimeout()),
 		}
 
 		if insecure {
 			options = append(options, otlptracegrpc.WithInsecure())
+		} else if tlsConfig != nil {
+			options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
 		}
 
-		traceExporter, err = otlptracegrpc.New(ctx, options...)
-		if err != nil {
-			return nil, err
-		}
-
-		return trace.NewTracerProvider(
-			trace.WithResource(resources),
-			trace.WithBatcher(traceExporter),
-		), nil
+		return otlptracegrpc.New(ctx, options...)
 	}
 
 	options := []otlptracehttp.Option{
 		otlptracehttp.WithEndpointURL(endpoint),
 		otlptracehttp.WithCompression(otlptracehttp.Compression(compressorInt)),
+		otlptracehttp.WithHeaders(config.GetOTLPTracesHeaders()),
+		otlptracehttp.WithTimeout(config.GetOTLPTracesTimeout()),
 	}
 
 	if insecure {
 		options = append(options, otlptracehttp.WithInsecure())
+	} else if tlsConfig != nil {
+		options = append(options, otlptracehttp.WithTLSClientConfig(tlsConfig))
 	}
 
-	traceExporter, err = otlptracehttp.New(ctx, options...)
-	if err != nil {
-		return nil, err
-	}
-
-	return trace.NewTracerProvider(
-		trace.WithResource(resources),
-		trace.WithBatcher(traceExporter),
-	), nil
+	return otlptracehttp.New(ctx, options...)
 }
 
 func setupOTelMetricsProvider(
@@ -202,62 +467,193 @@ func setupOTelMetricsProvider(
 	config *OTLPConfig,
 	resources *resource.Resource,
 	otelDisabled bool,
+	prometheusRegistry prometheus.Registerer,
+	setupOpts *setupOptions,
 ) (*metric.MeterProvider, error) {
-	// configure metrics exporter
-	metricsExporterType := config.GetMetricsExporter()
-	metricOptions := []metric.Option{metric.WithResource(resources)}
+	views, err := newMetricViews(config.MetricViews)
+	if err != nil {
+		return nil, err
+	}
 
-	var err error
+	exemplarFilter, err := newExemplarFilter(config.GetMetricsExemplarFilter())
+	if err != nil {
+		return nil, err
+	}
 
-	if config.DisableGoMetrics != nil && !*config.DisableGoMetrics {
-		// disable default process and go collector metrics
-		prometheus.Unregister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
-		prometheus.Unregister(collectors.NewGoCollector())
+	metricOptions := []metric.Option{
+		metric.WithResource(resources),
+		metric.WithView(append(views, setupOpts.views...)...),
+		metric.WithExemplarFilter(exemplarFilter),
 	}
 
+	if config.MetricsCardinalityLimit != nil {
+		metricOptions = append(metricOptions, metric.WithCardinalityLimit(*config.MetricsCardinalityLimit))
+	}
+
+	for _, reader := range setupOpts.metricReaders {
+		metricOptions = append(metricOptions, metric.WithReader(reader))
+	}
+
+	// create the configured reader first so that no periodic reader is started if it fails.
+	reader, err := newMetricReader(ctx, config, otelDisabled, prometheusRegistry)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, exporter := range setupOpts.metricExporters {
+		metricOptions = append(metricOptions, metric.WithReader(newPeriodicReader(config, exporter)))
+	}
+
+	if reader != nil {
+		metricOptions = append(metricOptions, metric.WithReader(reader))
+	}
+
+	overflowCounter := newMetricOverflowCounter()
+
+	// the overflow counter collects on its own schedule only if metrics are read and may overflow.
+	hasReader := reader != nil || len(setupOpts.metricReaders) > 0 || len(setupOpts.metricExporters) > 0
+	if hasReader && (config.MetricsCardinalityLimit == nil || *config.MetricsCardinalityLimit > 0) {
+		metricOptions = append(metricOptions, metric.WithReader(newPeriodicReader(config, overflowCounter)))
+	}
+
+	meterProvider := metric.NewMeterProvider(metricOptions...)
+
+	err = startMeterProviderMetrics(config, meterProvider, overflowCounter)
+	if err != nil {
+		return nil, errors.Join(err, meterProvider.Shutdown(ctx))
+	}
+
+	return meterProvider, nil
+}
+
+// register the metric overflow counter and start the Go runtime and host metrics of the meter provider.
+func startMeterProviderMetrics(
+	config *OTLPConfig,
+	meterProvider *metric.MeterProvider,
+	overflowCounter *metricOverflowCounter,
+) error {
+	err := overflowCounter.register(meterProvider.Meter(instrumentationScopeName))
+	if err != nil {
+		return fmt.Errorf("failed to register the metric overflow counter: %w", err)
+	}
+
+	err = setupGoMetrics(config, meterProvider)
+	if err != nil {
+		return fmt.Errorf("failed to start Go runtime metrics: %w", err)
+	}
+
+	err = setupHostMetrics(config, meterProvider)
+	if err != nil {
+		return fmt.Errorf("failed to start host metrics: %w", err)
+	}
+
+	return nil
+}
+
+// create the metric reader of the configured metrics exporter.
+// Returns nil if the exporter is disabled.
+func newMetricReader(
+	ctx context.Context,
+	config *OTLPConfig,
+	otelDisabled bool,
+	prometheusRegistry prometheus.Registerer,
+) (metric.Reader, error) {
+	var (
+		metricExporter metric.Exporter
+		readerOptions  []metric.PeriodicReaderOption
+		err            error
+	)
+
+	metricsExporterType := config.GetMetricsExporter()
+
 	switch metricsExporterType {
-	case OTELMetricsExporterPrometheus:
+	case OTELMetricsExporterPrometheus, OTELMetricsExporterPushgateway:
 		// The exporter embeds a default OpenTelemetry Reader and
 		// implements prometheus.Collector, allowing it to be used as
 		// both a Reader and Collector.
-		prometheusExporter, err := otelPrometheus.New()
+		prometheusExporter, err := newPrometheusExporter(config, prometheusRegistry)
 		if err != nil {
 			return nil, err
 		}
 
-		metricOptions = append(metricOptions, metric.WithReader(prometheusExporter))
+		return prometheusExporter, nil
 	case OTELMetricsExporterOTLP:
 		if otelDisabled {
-			break
+			return nil, nil
 		}
 
-		metricOptions, err = setupMetricExporterOTLP(ctx, config, metricOptions)
-		if err != nil {
-			return nil, err
+		metricExporter, err = setupMetricExporterOTLP(ctx, config)
+	case OTELMetricsExporterConsole:
+		if otelDisabled {
+			return nil, nil
+		}
+
+		metricExporter, err = stdoutmetric.New(stdoutmetric.WithPrettyPrint())
+	case OTELMetricsExporterFile:
+		if otelDisabled {
+			return nil, nil
+		}
+
+		metricExporter, err = newFileMetricExporter(ctx, config.GetMetricsFilePath())
+	case OTELMetricsExporterPrometheusRemoteWrite:
+		if otelDisabled {
+			return nil, nil
+		}
+
+		metricExporter, err = newRemoteWriteExporter(config)
+	case OTELMetricsExporterStatsd:
+		if otelDisabled {
+			return nil, nil
+		}
+
+		metricExporter, err = newStatsdExporter(config)
+
+		if config.StatsdFlushInterval != nil {
+			readerOptions = append(
+				readerOptions,
+				metric.WithInterval(time.Duration(*config.StatsdFlushInterval)*time.Millisecond),
+			)
 		}
 	case OTELMetricsExporterNone:
+		return nil, nil
 	default:
-		return nil, fmt.Errorf("%w: %s", errInvalidOTELMetricExporterType, metricsExporterType)
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOTELMetricExporterType, metricsExporterType)
 	}
 
-	meterProvider := metric.NewMeterProvider(metricOptions...)
-	otel.SetMeterProvider(meterProvider)
+	if err != nil {
+		return nil, err
+	}
 
-	return meterProvider, nil
+	return newPeriodicReader(config, metricExporter, readerOptions...), nil
 }
 
-func setupMetricExporterOTLP(
-	ctx context.Context,
+// create a periodic reader of the exporter.
+// The extra options override the general periodic reader options of the config.
+func newPeriodicReader(
 	config *OTLPConfig,
-	metricOptions []metric.Option,
-) ([]metric.Option, error) {
+	exporter metric.Exporter,
+	options ...metric.PeriodicReaderOption,
+) metric.Reader {
+	return metric.NewPeriodicReader(exporter, append(newPeriodicReaderOptions(config), options...)...)
+}
+
+// register Go runtime metrics to the meter provider so they are exported by any metrics exporter.
+func setupGoMetrics(config *OTLPConfig, meterProvider metricapi.MeterProvider) error {
+	if config.DisableGoMetrics != nil && *config.DisableGoMetrics {
+		return nil
+	}
+
+	return otelRuntime.Start(otelRuntime.WithMeterProvider(meterProvider))
+}
+
+func setupMetricExporterOTLP(ctx context.Context, config *OTLPConfig) (metric.Exporter, error) {
 	metricsEndpoint := config.OtlpMetricsEndpoint
 	if metricsEndpoint == "" && config.OtlpEndpoint != "" {
 		metricsEndpoint = config.OtlpEndpoint + "/v1/metrics"
 	}
 
 	if metricsEndpoint == "" {
-		return nil, errMetricsOTLPEndpointRequired
+		return nil, ErrMetricsOTLPEndpointRequired
 	}
 
 	endpoint, protocol, insecure, err := parseOTLPEndpoint(
@@ -276,68 +672,258 @@ func setupMetricExporterOTLP(
 		return nil, fmt.Errorf("failed to parse OTLP metrics compression: %w", err)
 	}
 
+	tlsConfig, err := newOTLPTLSConfig(
+		getDefault(config.OtlpMetricsCertificate, config.OtlpCertificate),
+		getDefault(config.OtlpMetricsClientCertificate, config.OtlpClientCertificate),
+		getDefault(config.OtlpMetricsClientKey, config.OtlpClientKey),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OTLP metrics TLS certificates: %w", err)
+	}
+
+	temporalitySelector, err := newOTLPMetricsTemporalitySelector(config.GetOTLPMetricsTemporalityPreference())
+	if err != nil {
+		return nil, err
+	}
+
+	aggregationSelector, err := newOTLPMetricsAggregationSelector(config.GetOTLPMetricsDefaultHistogramAggregation())
+	if err != nil {
+		return nil, err
+	}
+
 	if protocol == OTLPProtocolGRPC {
 		options := []otlpmetricgrpc.Option{
 			otlpmetricgrpc.WithEndpoint(endpoint),
 			otlpmetricgrpc.WithCompressor(string(compressorStr)),
+			otlpmetricgrpc.WithHeaders(config.GetOTLPMetricsHeaders()),
+			otlpmetricgrpc.WithTimeout(config.GetOTLPMetricsTimeout()),
+			otlpmetricgrpc.WithTemporalitySelector(temporalitySelector),
+			otlpmetricgrpc.WithAggregationSelector(aggregationSelector),
 		}
 
 		if insecure {
 			options = append(options, otlpmetricgrpc.WithInsecure())
+		} else if tlsConfig != nil {
+			options = append(options, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
 		}
 
-		metricExporter, err := otlpmetricgrpc.New(ctx, options...)
-		if err != nil {
-			return nil, err
-		}
-
-		return append(
-			metricOptions,
-			metric.WithReader(metric.NewPeriodicReader(metricExporter)),
-		), nil
+		return otlpmetricgrpc.New(ctx, options...)
 	}
 
 	options := []otlpmetrichttp.Option{
 		otlpmetrichttp.WithEndpointURL(endpoint),
 		otlpmetrichttp.WithCompression(otlpmetrichttp.Compression(compressorInt)),
+		otlpmetrichttp.WithHeaders(config.GetOTLPMetricsHeaders()),
+		otlpmetrichttp.WithTimeout(config.GetOTLPMetricsTimeout()),
+		otlpmetrichttp.WithTemporalitySelector(temporalitySelector),
+		otlpmetrichttp.WithAggregationSelector(aggregationSelector),
 	}
+
 	if insecure {
 		options = append(options, otlpmetrichttp.WithInsecure())
+	} else if tlsConfig != nil {
+		options = append(options, otlpmetrichttp.WithTLSClientConfig(tlsConfig))
+	}
+
+	return otlpmetrichttp.New(ctx, options...)
+}
+
+// create the filter of measurements that are sampled as exemplars.
+func newExemplarFilter(filterType OTELMetricsExemplarFilterType) (exemplar.Filter, error) {
+	switch filterType {
+	case OTELMetricsExemplarFilterTraceBased:
+		return exemplar.TraceBasedFilter, nil
+	case OTELMetricsExemplarFilterAlwaysOn:
+		return exemplar.AlwaysOnFilter, nil
+	case OTELMetricsExemplarFilterAlwaysOff:
+		return exemplar.AlwaysOffFilter, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOTELMetricsExemplarFilterType, filterType)
+	}
+}
+
+// create the temporality selector of the OTLP metrics exporter
+// following the OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE specification.
+func newOTLPMetricsTemporalitySelector(
+	preference OTLPMetricsTemporalityPreference,
+) (metric.TemporalitySelector, error) {
+	switch preference {
+	case OTLPMetricsTemporalityCumulative:
+		return metric.DefaultTemporalitySelector, nil
+	case OTLPMetricsTemporalityDelta:
+		return func(kind metric.InstrumentKind) metricdata.Temporality {
+			switch kind {
+			case metric.InstrumentKindCounter,
+				metric.InstrumentKindObservableCounter,
+				metric.InstrumentKindHistogram:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}, nil
+	case OTLPMetricsTemporalityLowMemory:
+		return func(kind metric.InstrumentKind) metricdata.Temporality {
+			switch kind {
+			case metric.InstrumentKindCounter, metric.InstrumentKindHistogram:
+				return metricdata.DeltaTemporality
+			default:
+				return metricdata.CumulativeTemporality
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOTLPMetricsTemporalityPreference, preference)
+	}
+}
+
+// create the aggregation selector of the OTLP metrics exporter
+// following the OTEL_EXPORTER_OTLP_METRICS_DEFAULT_HISTOGRAM_AGGREGATION specification.
+func newOTLPMetricsAggregationSelector(
+	histogramAggregation OTELMetricAggregationType,
+) (metric.AggregationSelector, error) {
+	switch histogramAggregation {
+	case OTELMetricAggregationExplicitBucketHistogram:
+		return metric.DefaultAggregationSelector, nil
+	case OTELMetricAggregationBase2ExponentialBucketHistogram:
+		return func(kind metric.InstrumentKind) metric.Aggregation {
+			if kind == metric.InstrumentKindHistogram {
+				return metric.AggregationBase2ExponentialHistogram{
+					MaxSize:  defaultExponentialHistogramMaxSize,
+					MaxScale: defaultExponentialHistogramMaxScale,
+				}
+			}
+
+			return metric.DefaultAggregationSelector(kind)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOTELMetricAggregationType, histogramAggregation)
+	}
+}
+
+func newPeriodicReaderOptions(config *OTLPConfig) []metric.PeriodicReaderOption {
+	options := []metric.PeriodicReaderOption{}
+
+	if config.MetricsExportInterval != nil {
+		options = append(options, metric.WithInterval(time.Duration(*config.MetricsExportInterval)*time.Millisecond))
+	}
+
+	if config.MetricsExportTimeout != nil {
+		options = append(options, metric.WithTimeout(time.Duration(*config.MetricsExportTimeout)*time.Millisecond))
+	}
+
+	return options
+}
+
+func newBatchSpanProcessorOptions(config *OTLPConfig) []trace.BatchSpanProcessorOption {
+	options := []trace.BatchSpanProcessorOption{}
+
+	if config.TracesMaxQueueSize != nil {
+		options = append(options, trace.WithMaxQueueSize(int(*config.TracesMaxQueueSize)))
+	}
+
+	if config.TracesMaxExportBatchSize != nil {
+		options = append(options, trace.WithMaxExportBatchSize(int(*config.TracesMaxExportBatchSize)))
 	}
 
-	metricExporter, err := otlpmetrichttp.New(ctx, options...)
+	if config.TracesExportTimeout != nil {
+		options = append(options, trace.WithExportTimeout(time.Duration(*config.TracesExportTimeout)*time.Millisecond))
+	}
+
+	if config.TracesScheduleDelay != nil {
+		options = append(options, trace.WithBatchTimeout(time.Duration(*config.TracesScheduleDelay)*time.Millisecond))
+	}
+
+	return options
+}
+
+// create the resource from attributes of the configured detectors.
+// Attributes are applied in order of precedence from low to high:
+//   - attributes of detectors.
+//   - OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME if the env detector is enabled.
+//   - resourceAttributes of the configuration.
+//   - serviceNamespace, serviceInstanceId and serviceName of the configuration if set.
+//   - the service version and SDK attributes.
+func newResource(config *OTLPConfig, serviceVersion string) (*resource.Resource, error) {
+	attrs, err := detectResourceAttributes(config.GetResourceDetectors())
 	if err != nil {
 		return nil, err
 	}
 
-	metricOptions = append(
-		metricOptions,
-		metric.WithReader(metric.NewPeriodicReader(metricExporter)),
-	)
+	for key, value := range config.ResourceAttributes {
+		attrs = append(attrs, attribute.String(key, value))
+	}
 
-	return metricOptions, nil
-}
+	if config.ServiceNamespace != "" {
+		attrs = append(attrs, semconv.ServiceNamespace(config.ServiceNamespace))
+	}
 
-func newResource(serviceName, serviceVersion string) *resource.Resource {
-	hostname, _ := os.Hostname()
-	attrs := append(
-		resource.Environment().Attributes(),
-		semconv.ServiceName(serviceName),
+	if config.ServiceInstanceID != "" {
+		attrs = append(attrs, semconv.ServiceInstanceID(config.ServiceInstanceID))
+	}
+
+	if config.ServiceName != "" {
+		attrs = append(attrs, semconv.ServiceName(config.ServiceName))
+	}
+
+	attrs = append(
+		attrs,
 		semconv.ServiceVersion(serviceVersion),
-		semconv.HostNameKey.String(hostname),
 		semconv.TelemetrySDKLanguageGo,
 		semconv.TelemetrySDKVersion(sdk.Version()),
-		semconv.ProcessPIDKey.Int64(int64(os.Getpid())),
 	)
 
-	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
 }
 
-func newPropagator() propagation.TextMapPropagator {
-	return propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)),
-	)
+func newTraceSampler(config *OTLPConfig) (trace.Sampler, error) {
+	samplerType := config.GetTracesSampler()
+
+	switch samplerType {
+	case OTELTracesSamplerAlwaysOn:
+		return trace.AlwaysSample(), nil
+	case OTELTracesSamplerAlwaysOff:
+		return trace.NeverSample(), nil
+	case OTELTracesSamplerParentBasedAlwaysOn:
+		return trace.ParentBased(trace.AlwaysSample()), nil
+	case OTELTracesSamplerParentBasedAlwaysOff:
+		return trace.ParentBased(trace.NeverSample()), nil
+	case OTELTracesSamplerTraceIDRatio, OTELTracesSamplerParentBasedTraceIDRatio:
+		ratio := config.GetTracesSamplerArg()
+		if ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOTELTracesSamplerArg, ratio)
+		}
+
+		if samplerType == OTELTracesSamplerTraceIDRatio {
+			return trace.TraceIDRatioBased(ratio), nil
+		}
+
+		return trace.ParentBased(trace.TraceIDRatioBased(ratio)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOTELTracesSamplerType, samplerType)
+	}
+}
+
+func newPropagator(propagatorTypes []OTELPropagatorType) (propagation.TextMapPropagator, error) {
+	propagators := make([]propagation.TextMapPropagator, 0, len(propagatorTypes))
+
+	for _, propagatorType := range propagatorTypes {
+		switch propagatorType {
+		case OTELPropagatorTraceContext:
+			propagators = append(propagators, propagation.TraceContext{})
+		case OTELPropagatorBaggage:
+			propagators = append(propagators, propagation.Baggage{})
+		case OTELPropagatorB3:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case OTELPropagatorB3Multi:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case OTELPropagatorJaeger:
+			propagators = append(propagators, jaeger.Jaeger{})
+		case OTELPropagatorNone:
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidOTELPropagatorType, propagatorType)
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
 }
 
 func parseOTLPEndpoint(
@@ -379,10 +965,53 @@ func parseOTLPEndpoint(
 
 		return host, OTLPProtocolGRPC, insecure, nil
 	default:
-		return "", "", false, fmt.Errorf("%w: %s", errInvalidOTLPProtocol, protocol)
+		return "", "", false, fmt.Errorf("%w: %s", ErrInvalidOTLPProtocol, protocol)
 	}
 }
 
+// load the TLS configuration of OTLP exporters from PEM-encoded certificate files.
+// Returns nil if no certificate is configured.
+func newOTLPTLSConfig(certificateFile, clientCertificateFile, clientKeyFile string) (*tls.Config, error) {
+	if certificateFile == "" && clientCertificateFile == "" && clientKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if certificateFile != "" {
+		caPEM, err := os.ReadFile(certificateFile)
+		if err != nil {
+			return nil, err
+		}
+
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidOTLPCertificate, certificateFile)
+		}
+
+		tlsConfig.RootCAs = certPool
+	}
+
+	if clientCertificateFile == "" && clientKeyFile == "" {
+		return tlsConfig, nil
+	}
+
+	if clientCertificateFile == "" || clientKeyFile == "" {
+		return nil, ErrOTLPClientKeyPairRequired
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertificateFile, clientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.Certificates = []tls.Certificate{clientCert}
+
+	return tlsConfig, nil
+}
+
 func parseOTLPCompression(input OTLPCompressionType) (OTLPCompressionType, int, error) {
 	switch input {
 	case OTLPCompressionGzip, "":
@@ -390,6 +1019,6 @@ func parseOTLPCompression(input OTLPCompressionType) (OTLPCompressionType, int,
 	case OTLPCompressionNone:
 		return input, int(otlptracehttp.NoCompression), nil
 	default:
-		return "", 0, errInvalidOTLPCompressionType
+		return "", 0, ErrInvalidOTLPCompressionType
 	}
 }
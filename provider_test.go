@@ -1,7 +1,33 @@
 package gotel
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	traceapi "go.opentelemetry.io/otel/trace"
 )
 
 // Helper function to create bool pointers
@@ -9,6 +35,11 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+// Helper function to create uint pointers
+func uintPtr(u uint) *uint {
+	return &u
+}
+
 func TestParseOTLPEndpoint(t *testing.T) {
 	testCases := []struct {
 		Name             string
@@ -216,7 +247,10 @@ func TestNewResource(t *testing.T) {
 		serviceName := "test-service"
 		serviceVersion := "v1.0.0"
 
-		resource := newResource(serviceName, serviceVersion)
+		resource, err := newResource(&OTLPConfig{ServiceName: serviceName}, serviceVersion)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 
 		if resource == nil {
 			t.Fatal("expected non-nil resource")
@@ -250,18 +284,886 @@ func TestNewResource(t *testing.T) {
 	})
 }
 
+func TestNewResource_AttributePrecedence(t *testing.T) {
+	t.Setenv(
+		"OTEL_RESOURCE_ATTRIBUTES",
+		"service.name=env-service,service.namespace=env-namespace,service.instance.id=env-instance,team=env,tier=env",
+	)
+
+	testCases := []struct {
+		Name     string
+		Config   OTLPConfig
+		Expected map[attribute.Key]string
+	}{
+		{
+			Name: "env takes precedence over detectors",
+			Config: OTLPConfig{
+				ResourceDetectors: []OTELResourceDetectorType{OTELResourceDetectorService, OTELResourceDetectorEnv},
+			},
+			Expected: map[attribute.Key]string{
+				"service.name":        "env-service",
+				"service.namespace":   "env-namespace",
+				"service.instance.id": "env-instance",
+				"team":                "env",
+			},
+		},
+		{
+			Name: "config takes precedence over env",
+			Config: OTLPConfig{
+				ServiceName:       "config-service",
+				ServiceNamespace:  "config-namespace",
+				ServiceInstanceID: "config-instance",
+				ResourceAttributes: map[string]string{
+					"service.namespace": "attributes-namespace",
+					"team":              "config",
+				},
+			},
+			Expected: map[attribute.Key]string{
+				"service.name":        "config-service",
+				"service.namespace":   "config-namespace",
+				"service.instance.id": "config-instance",
+				"team":                "config",
+				"tier":                "env",
+			},
+		},
+		{
+			Name: "resource attributes take precedence over env",
+			Config: OTLPConfig{
+				ResourceAttributes: map[string]string{
+					"service.namespace": "attributes-namespace",
+				},
+			},
+			Expected: map[attribute.Key]string{
+				"service.name":      "env-service",
+				"service.namespace": "attributes-namespace",
+			},
+		},
+		{
+			Name: "service instance ID is not set without detectors",
+			Config: OTLPConfig{
+				ResourceDetectors: []OTELResourceDetectorType{OTELResourceDetectorNone},
+				ServiceName:       "config-service",
+			},
+			Expected: map[attribute.Key]string{
+				"service.name":        "config-service",
+				"service.instance.id": "",
+				"team":                "",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			res, err := newResource(&tc.Config, "v1.0.0")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			attrs := attributesToMap(res.Attributes())
+
+			for key, value := range tc.Expected {
+				if attrs[key] != value {
+					t.Errorf("expected %s to be %q, got %q", key, value, attrs[key])
+				}
+			}
+		})
+	}
+}
+
 func TestNewPropagator(t *testing.T) {
-	t.Run("creates composite propagator", func(t *testing.T) {
-		propagator := newPropagator()
+	testCases := []struct {
+		Name           string
+		Propagators    []OTELPropagatorType
+		ExpectedFields []string
+		ExpectError    bool
+	}{
+		{
+			Name:           "default propagators",
+			Propagators:    defaultPropagators,
+			ExpectedFields: []string{"traceparent", "tracestate", "x-b3-traceid", "x-b3-spanid", "x-b3-sampled"},
+		},
+		{
+			Name:           "tracecontext and baggage",
+			Propagators:    []OTELPropagatorType{OTELPropagatorTraceContext, OTELPropagatorBaggage},
+			ExpectedFields: []string{"traceparent", "tracestate", "baggage"},
+		},
+		{
+			Name:           "b3 single header",
+			Propagators:    []OTELPropagatorType{OTELPropagatorB3},
+			ExpectedFields: []string{"b3"},
+		},
+		{
+			Name:           "jaeger",
+			Propagators:    []OTELPropagatorType{OTELPropagatorJaeger},
+			ExpectedFields: []string{"uber-trace-id"},
+		},
+		{
+			Name:           "none",
+			Propagators:    []OTELPropagatorType{OTELPropagatorNone},
+			ExpectedFields: []string{},
+		},
+		{
+			Name:        "invalid propagator",
+			Propagators: []OTELPropagatorType{"invalid"},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			propagator, err := newPropagator(tc.Propagators)
+			if tc.ExpectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			fields := propagator.Fields()
+			for _, expected := range tc.ExpectedFields {
+				if !slices.Contains(fields, expected) {
+					t.Errorf("expected propagator fields %v to contain %s", fields, expected)
+				}
+			}
+
+			if len(tc.ExpectedFields) == 0 && len(fields) != 0 {
+				t.Errorf("expected no propagator fields, got %v", fields)
+			}
+		})
+	}
+}
+
+func TestSetupOTelExporters_PropagatorWithoutTracesExport(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	config := &OTLPConfig{
+		ServiceName:    "propagator-test",
+		TracesExporter: OTELTracesExporterNone,
+		Propagators:    []OTELPropagatorType{OTELPropagatorTraceContext, OTELPropagatorBaggage},
+	}
+
+	// reset the global propagator that may be installed by other tests.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+	defer exporters.Shutdown(context.Background())
+
+	traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	header := http.Header{}
+	header.Set("traceparent", traceParent)
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+	ctx, span := exporters.Tracer.Start(ctx, "child-span")
+	defer span.End()
+
+	if span.SpanContext().TraceID().String() != "0af7651916cd43dd8448eb211c80319c" {
+		t.Errorf("expected the trace ID to be propagated, got %s", span.SpanContext().TraceID())
+	}
+
+	outgoing := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(outgoing))
+
+	if !strings.HasPrefix(outgoing.Get("traceparent"), "00-0af7651916cd43dd8448eb211c80319c-") {
+		t.Errorf("expected the traceparent header to be injected, got %s", outgoing.Get("traceparent"))
+	}
+}
+
+func TestNewTraceSampler(t *testing.T) {
+	ratio := 0.5
+	invalidRatio := 1.5
+
+	testCases := []struct {
+		Name                string
+		Config              OTLPConfig
+		ExpectedDescription string
+		ExpectError         bool
+	}{
+		{
+			Name:                "default is parent based always on",
+			Config:              OTLPConfig{},
+			ExpectedDescription: "ParentBased{root:AlwaysOnSampler,",
+		},
+		{
+			Name:                "always on",
+			Config:              OTLPConfig{TracesSampler: OTELTracesSamplerAlwaysOn},
+			ExpectedDescription: "AlwaysOnSampler",
+		},
+		{
+			Name:                "always off",
+			Config:              OTLPConfig{TracesSampler: OTELTracesSamplerAlwaysOff},
+			ExpectedDescription: "AlwaysOffSampler",
+		},
+		{
+			Name:                "parent based always off",
+			Config:              OTLPConfig{TracesSampler: OTELTracesSamplerParentBasedAlwaysOff},
+			ExpectedDescription: "ParentBased{root:AlwaysOffSampler,",
+		},
+		{
+			Name: "trace id ratio",
+			Config: OTLPConfig{
+				TracesSampler:    OTELTracesSamplerTraceIDRatio,
+				TracesSamplerArg: &ratio,
+			},
+			ExpectedDescription: "TraceIDRatioBased{0.5}",
+		},
+		{
+			Name: "parent based trace id ratio",
+			Config: OTLPConfig{
+				TracesSampler:    OTELTracesSamplerParentBasedTraceIDRatio,
+				TracesSamplerArg: &ratio,
+			},
+			ExpectedDescription: "ParentBased{root:TraceIDRatioBased{0.5},",
+		},
+		{
+			Name:                "trace id ratio defaults to 1",
+			Config:              OTLPConfig{TracesSampler: OTELTracesSamplerTraceIDRatio},
+			ExpectedDescription: "TraceIDRatioBased{1}",
+		},
+		{
+			Name: "invalid ratio returns error",
+			Config: OTLPConfig{
+				TracesSampler:    OTELTracesSamplerTraceIDRatio,
+				TracesSamplerArg: &invalidRatio,
+			},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid sampler returns error",
+			Config:      OTLPConfig{TracesSampler: "invalid"},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sampler, err := newTraceSampler(&tc.Config)
+
+			if tc.ExpectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasPrefix(sampler.Description(), tc.ExpectedDescription) {
+				t.Errorf("expected description '%s', got '%s'", tc.ExpectedDescription, sampler.Description())
+			}
+		})
+	}
+}
+
+// Helper function to write a self-signed certificate and its private key to PEM files
+func writeTestCertificate(t *testing.T, dir string, name string) (string, string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestNewOTLPTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	caFile, _ := writeTestCertificate(t, dir, "ca")
+	clientCertFile, clientKeyFile := writeTestCertificate(t, dir, "client")
+
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidFile, []byte("invalid"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("returns nil without certificates", func(t *testing.T) {
+		tlsConfig, err := newOTLPTLSConfig("", "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if tlsConfig != nil {
+			t.Error("expected nil TLS config")
+		}
+	})
+
+	t.Run("loads trusted certificate", func(t *testing.T) {
+		tlsConfig, err := newOTLPTLSConfig(caFile, "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if tlsConfig.RootCAs == nil {
+			t.Error("expected RootCAs to be set")
+		}
+
+		if len(tlsConfig.Certificates) != 0 {
+			t.Error("expected no client certificates")
+		}
+	})
+
+	t.Run("loads client certificate and key", func(t *testing.T) {
+		tlsConfig, err := newOTLPTLSConfig(caFile, clientCertFile, clientKeyFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tlsConfig.Certificates) != 1 {
+			t.Errorf("expected 1 client certificate, got %d", len(tlsConfig.Certificates))
+		}
+	})
+
+	t.Run("invalid trusted certificate returns error", func(t *testing.T) {
+		_, err := newOTLPTLSConfig(invalidFile, "", "")
+		if !errors.Is(err, ErrInvalidOTLPCertificate) {
+			t.Errorf("expected ErrInvalidOTLPCertificate, got %v", err)
+		}
+	})
+
+	t.Run("missing certificate file returns error", func(t *testing.T) {
+		_, err := newOTLPTLSConfig(filepath.Join(dir, "missing.pem"), "", "")
+		if err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+
+	t.Run("client certificate without key returns error", func(t *testing.T) {
+		_, err := newOTLPTLSConfig("", clientCertFile, "")
+		if !errors.Is(err, ErrOTLPClientKeyPairRequired) {
+			t.Errorf("expected ErrOTLPClientKeyPairRequired, got %v", err)
+		}
+	})
+}
+
+func TestSetupOTelExporters_OTLPHeadersAndTLS(t *testing.T) {
+	requestHeaders := make(chan http.Header, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			select {
+			case requestHeaders <- r.Header.Clone():
+			default:
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := uint(2000)
+	config := &OTLPConfig{
+		ServiceName:        "tls-test",
+		OtlpTracesEndpoint: server.URL + "/v1/traces",
+		OtlpTracesProtocol: OTLPProtocolHTTPProtobuf,
+		OtlpHeaders: map[string]string{
+			"x-api-key": "secret",
+		},
+		OtlpTracesHeaders: map[string]string{
+			"x-signal": "traces",
+		},
+		OtlpTimeout:     &timeout,
+		OtlpCertificate: caFile,
+	}
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	_, span := exporters.Tracer.Start(context.Background(), "test-span")
+	span.End()
+
+	if err := exporters.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shutdown exporters: %v", err)
+	}
+
+	select {
+	case headers := <-requestHeaders:
+		if headers.Get("x-api-key") != "secret" {
+			t.Errorf("expected x-api-key header 'secret', got '%s'", headers.Get("x-api-key"))
+		}
+
+		if headers.Get("x-signal") != "traces" {
+			t.Errorf("expected x-signal header 'traces', got '%s'", headers.Get("x-signal"))
+		}
+	default:
+		t.Fatal("expected the traces exporter to send a request over TLS")
+	}
+}
+
+func TestSetupOTelExporters_TracesExporter(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	testCases := []struct {
+		Name             string
+		TracesExporter   OTELTracesExporterType
+		SdkDisabled      *bool
+		ExpectedRequests int32
+	}{
+		{
+			Name:             "exports traces by default",
+			ExpectedRequests: 1,
+		},
+		{
+			Name:             "exports traces with otlp exporter",
+			TracesExporter:   OTELTracesExporterOTLP,
+			ExpectedRequests: 1,
+		},
+		{
+			Name:             "does not export traces with none exporter",
+			TracesExporter:   OTELTracesExporterNone,
+			ExpectedRequests: 0,
+		},
+		{
+			Name:             "does not export traces if the SDK is disabled",
+			TracesExporter:   OTELTracesExporterOTLP,
+			SdkDisabled:      boolPtr(true),
+			ExpectedRequests: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var tracesRequests atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/traces" {
+					tracesRequests.Add(1)
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			config := &OTLPConfig{
+				ServiceName:     "traces-exporter-test",
+				OtlpEndpoint:    server.URL,
+				OtlpProtocol:    OTLPProtocolHTTPProtobuf,
+				TracesExporter:  tc.TracesExporter,
+				MetricsExporter: OTELMetricsExporterOTLP,
+				SdkDisabled:     tc.SdkDisabled,
+			}
+
+			exporters, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger)
+			if err != nil {
+				t.Fatalf("failed to setup exporters: %v", err)
+			}
+
+			_, span := exporters.Tracer.Start(context.Background(), "test-span")
+			span.End()
+
+			if err := exporters.Shutdown(context.Background()); err != nil {
+				t.Fatalf("failed to shutdown exporters: %v", err)
+			}
+
+			if tracesRequests.Load() != tc.ExpectedRequests {
+				t.Errorf("expected %d traces requests, got %d", tc.ExpectedRequests, tracesRequests.Load())
+			}
+		})
+	}
+}
+
+func TestNewBatchSpanProcessorOptions(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        OTLPConfig
+		ExpectedCount int
+	}{
+		{
+			Name:          "no options by default",
+			Config:        OTLPConfig{},
+			ExpectedCount: 0,
+		},
+		{
+			Name: "all options",
+			Config: OTLPConfig{
+				TracesMaxQueueSize:       uintPtr(1024),
+				TracesMaxExportBatchSize: uintPtr(256),
+				TracesExportTimeout:      uintPtr(1000),
+				TracesScheduleDelay:      uintPtr(100),
+			},
+			ExpectedCount: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			options := newBatchSpanProcessorOptions(&tc.Config)
+			if len(options) != tc.ExpectedCount {
+				t.Errorf("expected %d options, got %d", tc.ExpectedCount, len(options))
+			}
+		})
+	}
+}
+
+func TestNewPeriodicReaderOptions(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        OTLPConfig
+		ExpectedCount int
+	}{
+		{
+			Name:          "no options by default",
+			Config:        OTLPConfig{},
+			ExpectedCount: 0,
+		},
+		{
+			Name: "all options",
+			Config: OTLPConfig{
+				MetricsExportInterval: uintPtr(1000),
+				MetricsExportTimeout:  uintPtr(500),
+			},
+			ExpectedCount: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			options := newPeriodicReaderOptions(&tc.Config)
+			if len(options) != tc.ExpectedCount {
+				t.Errorf("expected %d options, got %d", tc.ExpectedCount, len(options))
+			}
+		})
+	}
+}
+
+func TestNewExemplarFilter(t *testing.T) {
+	sampledCtx := traceapi.ContextWithSpanContext(context.Background(), traceapi.NewSpanContext(traceapi.SpanContextConfig{
+		TraceID:    traceapi.TraceID{0x01},
+		SpanID:     traceapi.SpanID{0x01},
+		TraceFlags: traceapi.FlagsSampled,
+	}))
+
+	testCases := []struct {
+		FilterType      OTELMetricsExemplarFilterType
+		ExpectedSampled bool
+		ExpectedNoTrace bool
+	}{
+		{
+			FilterType:      OTELMetricsExemplarFilterTraceBased,
+			ExpectedSampled: true,
+		},
+		{
+			FilterType:      OTELMetricsExemplarFilterAlwaysOn,
+			ExpectedSampled: true,
+			ExpectedNoTrace: true,
+		},
+		{
+			FilterType: OTELMetricsExemplarFilterAlwaysOff,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.FilterType), func(t *testing.T) {
+			filter, err := newExemplarFilter(tc.FilterType)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result := filter(sampledCtx); result != tc.ExpectedSampled {
+				t.Errorf("expected %v in a sampled span, got %v", tc.ExpectedSampled, result)
+			}
+
+			if result := filter(context.Background()); result != tc.ExpectedNoTrace {
+				t.Errorf("expected %v without span, got %v", tc.ExpectedNoTrace, result)
+			}
+		})
+	}
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := newExemplarFilter("parent_based")
+		if !errors.Is(err, ErrInvalidOTELMetricsExemplarFilterType) {
+			t.Errorf("expected error %v, got: %v", ErrInvalidOTELMetricsExemplarFilterType, err)
+		}
+	})
+}
 
-		if propagator == nil {
-			t.Fatal("expected non-nil propagator")
+func TestNewOTLPMetricsTemporalitySelector(t *testing.T) {
+	testCases := []struct {
+		Preference OTLPMetricsTemporalityPreference
+		Expected   map[metric.InstrumentKind]metricdata.Temporality
+	}{
+		{
+			Preference: OTLPMetricsTemporalityCumulative,
+			Expected: map[metric.InstrumentKind]metricdata.Temporality{
+				metric.InstrumentKindCounter:                 metricdata.CumulativeTemporality,
+				metric.InstrumentKindObservableCounter:       metricdata.CumulativeTemporality,
+				metric.InstrumentKindHistogram:               metricdata.CumulativeTemporality,
+				metric.InstrumentKindUpDownCounter:           metricdata.CumulativeTemporality,
+				metric.InstrumentKindObservableUpDownCounter: metricdata.CumulativeTemporality,
+			},
+		},
+		{
+			Preference: OTLPMetricsTemporalityDelta,
+			Expected: map[metric.InstrumentKind]metricdata.Temporality{
+				metric.InstrumentKindCounter:                 metricdata.DeltaTemporality,
+				metric.InstrumentKindObservableCounter:       metricdata.DeltaTemporality,
+				metric.InstrumentKindHistogram:               metricdata.DeltaTemporality,
+				metric.InstrumentKindUpDownCounter:           metricdata.CumulativeTemporality,
+				metric.InstrumentKindObservableUpDownCounter: metricdata.CumulativeTemporality,
+			},
+		},
+		{
+			Preference: OTLPMetricsTemporalityLowMemory,
+			Expected: map[metric.InstrumentKind]metricdata.Temporality{
+				metric.InstrumentKindCounter:                 metricdata.DeltaTemporality,
+				metric.InstrumentKindObservableCounter:       metricdata.CumulativeTemporality,
+				metric.InstrumentKindHistogram:               metricdata.DeltaTemporality,
+				metric.InstrumentKindUpDownCounter:           metricdata.CumulativeTemporality,
+				metric.InstrumentKindObservableUpDownCounter: metricdata.CumulativeTemporality,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.Preference), func(t *testing.T) {
+			selector, err := newOTLPMetricsTemporalitySelector(tc.Preference)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for kind, expected := range tc.Expected {
+				if result := selector(kind); result != expected {
+					t.Errorf("expected temporality %s of %s, got %s", expected, kind, result)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid preference", func(t *testing.T) {
+		_, err := newOTLPMetricsTemporalitySelector("stateless")
+		if !errors.Is(err, ErrInvalidOTLPMetricsTemporalityPreference) {
+			t.Errorf("expected error %v, got: %v", ErrInvalidOTLPMetricsTemporalityPreference, err)
+		}
+	})
+}
+
+func TestNewOTLPMetricsAggregationSelector(t *testing.T) {
+	t.Run("explicit bucket histogram", func(t *testing.T) {
+		selector, err := newOTLPMetricsAggregationSelector(OTELMetricAggregationExplicitBucketHistogram)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
 
-		// The propagator should have fields (TraceContext and B3)
-		fields := propagator.Fields()
-		if len(fields) == 0 {
-			t.Error("expected propagator to have fields")
+		if _, ok := selector(metric.InstrumentKindHistogram).(metric.AggregationExplicitBucketHistogram); !ok {
+			t.Errorf("expected explicit bucket histogram, got %T", selector(metric.InstrumentKindHistogram))
 		}
 	})
+
+	t.Run("base2 exponential bucket histogram", func(t *testing.T) {
+		selector, err := newOTLPMetricsAggregationSelector(OTELMetricAggregationBase2ExponentialBucketHistogram)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, ok := selector(metric.InstrumentKindHistogram).(metric.AggregationBase2ExponentialHistogram); !ok {
+			t.Errorf("expected base2 exponential histogram, got %T", selector(metric.InstrumentKindHistogram))
+		}
+
+		if _, ok := selector(metric.InstrumentKindCounter).(metric.AggregationSum); !ok {
+			t.Errorf("expected sum aggregation of counters, got %T", selector(metric.InstrumentKindCounter))
+		}
+	})
+
+	t.Run("invalid aggregation", func(t *testing.T) {
+		_, err := newOTLPMetricsAggregationSelector(OTELMetricAggregationSum)
+		if !errors.Is(err, ErrInvalidOTELMetricAggregationType) {
+			t.Errorf("expected error %v, got: %v", ErrInvalidOTELMetricAggregationType, err)
+		}
+	})
+}
+
+func TestOTelExporters_ForceFlush(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	var tracesRequests, metricsRequests, logsRequests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/traces":
+			tracesRequests.Add(1)
+		case "/v1/metrics":
+			metricsRequests.Add(1)
+		case "/v1/logs":
+			logsRequests.Add(1)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// use long delays so that nothing is exported before the flush.
+	config := &OTLPConfig{
+		ServiceName:           "force-flush-test",
+		OtlpEndpoint:          server.URL,
+		OtlpProtocol:          OTLPProtocolHTTPProtobuf,
+		MetricsExporter:       OTELMetricsExporterOTLP,
+		LogsExporter:          OTELLogsExporterOTLP,
+		TracesScheduleDelay:   uintPtr(60000),
+		LogsScheduleDelay:     uintPtr(60000),
+		MetricsExportInterval: uintPtr(60000),
+	}
+
+	exporters, err := SetupOTelExporters(
+		context.Background(),
+		config,
+		"v1.0.0",
+		logger,
+		WithGlobalRegistration(false),
+	)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	defer exporters.Shutdown(context.Background())
+
+	ctx, span := exporters.Tracer.Start(context.Background(), "flush-span")
+
+	counter, err := exporters.Meter.Int64Counter("flush.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	counter.Add(ctx, 1)
+	exporters.Logger.InfoContext(ctx, "hello flush")
+	span.End()
+
+	if err := exporters.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("failed to flush exporters: %v", err)
+	}
+
+	if tracesRequests.Load() != 1 {
+		t.Errorf("expected 1 traces request, got %d", tracesRequests.Load())
+	}
+
+	if metricsRequests.Load() != 1 {
+		t.Errorf("expected 1 metrics request, got %d", metricsRequests.Load())
+	}
+
+	if logsRequests.Load() != 1 {
+		t.Errorf("expected 1 logs request, got %d", logsRequests.Load())
+	}
+}
+
+func TestOTelExporters_ForceFlushWithoutProviders(t *testing.T) {
+	exporters := &OTelExporters{}
+
+	if err := exporters.ForceFlush(context.Background()); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestSetupOTelExporters_GoMetrics(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	testCases := []struct {
+		Name             string
+		DisableGoMetrics *bool
+		Expected         bool
+	}{
+		{
+			Name:     "registers Go runtime metrics by default",
+			Expected: true,
+		},
+		{
+			Name:             "registers Go runtime metrics if not disabled",
+			DisableGoMetrics: boolPtr(false),
+			Expected:         true,
+		},
+		{
+			Name:             "disables Go runtime metrics",
+			DisableGoMetrics: boolPtr(true),
+			Expected:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			metricReader := metric.NewManualReader()
+			config := &OTLPConfig{
+				ServiceName:      "go-metrics-test",
+				TracesExporter:   OTELTracesExporterNone,
+				DisableGoMetrics: tc.DisableGoMetrics,
+			}
+
+			exporters, err := SetupOTelExporters(
+				context.Background(),
+				config,
+				"v1.0.0",
+				logger,
+				WithMetricReader(metricReader),
+				WithGlobalRegistration(false),
+			)
+			if err != nil {
+				t.Fatalf("failed to setup exporters: %v", err)
+			}
+
+			var metrics metricdata.ResourceMetrics
+			if err := metricReader.Collect(context.Background(), &metrics); err != nil {
+				t.Fatalf("failed to collect metrics: %v", err)
+			}
+
+			if err := exporters.Shutdown(context.Background()); err != nil {
+				t.Fatalf("failed to shutdown exporters: %v", err)
+			}
+
+			names := []string{}
+
+			for _, scopeMetrics := range metrics.ScopeMetrics {
+				for _, m := range scopeMetrics.Metrics {
+					names = append(names, m.Name)
+				}
+			}
+
+			for _, name := range []string{"go.goroutine.count", "go.memory.used", "go.memory.gc.goal"} {
+				if slices.Contains(names, name) != tc.Expected {
+					t.Errorf("expected metric %s to exist: %t, got %v", name, tc.Expected, names)
+				}
+			}
+		})
+	}
 }
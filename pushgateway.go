@@ -0,0 +1,121 @@
+package gotel
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const (
+	defaultPushgatewayInterval = 60 * time.Second
+	defaultPushgatewayTimeout  = 30 * time.Second
+)
+
+// pushgatewayExporter pushes metrics of the Prometheus registry to a Pushgateway periodically and on shutdown,
+// so metrics of short-lived jobs are not lost before they are scraped.
+type pushgatewayExporter struct {
+	pusher   *push.Pusher
+	interval time.Duration
+	timeout  time.Duration
+	logger   *slog.Logger
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	// serializes pushes so the final push on shutdown is not overwritten by a periodic push.
+	mu sync.Mutex
+}
+
+// create the Pushgateway exporter of the registry.
+// The job name defaults to the service name.
+func newPushgatewayExporter(
+	config *OTLPConfig,
+	gatherer prometheus.Gatherer,
+	logger *slog.Logger,
+) (*pushgatewayExporter, error) {
+	if config.PushgatewayURL == "" {
+		return nil, ErrPushgatewayURLRequired
+	}
+
+	job := config.GetPushgatewayJob()
+	if job == "" {
+		return nil, ErrPushgatewayJobRequired
+	}
+
+	pusher := push.New(config.PushgatewayURL, job).Gatherer(gatherer)
+
+	for name, value := range config.PushgatewayGroupingLabels {
+		pusher = pusher.Grouping(name, value)
+	}
+
+	exporter := &pushgatewayExporter{
+		pusher:   pusher,
+		interval: defaultPushgatewayInterval,
+		timeout:  defaultPushgatewayTimeout,
+		logger:   logger,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if config.MetricsExportInterval != nil {
+		exporter.interval = time.Duration(*config.MetricsExportInterval) * time.Millisecond
+	}
+
+	if config.MetricsExportTimeout != nil {
+		exporter.timeout = time.Duration(*config.MetricsExportTimeout) * time.Millisecond
+	}
+
+	return exporter, nil
+}
+
+// start pushing metrics in the background on every interval.
+func (pe *pushgatewayExporter) start() {
+	go func() {
+		defer close(pe.done)
+
+		ticker := time.NewTicker(pe.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-pe.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), pe.timeout)
+
+				err := pe.push(ctx)
+				if err != nil {
+					pe.logger.Error("failed to push metrics to the Pushgateway: " + err.Error())
+				}
+
+				cancel()
+			}
+		}
+	}()
+}
+
+// push replaces metrics of the job and grouping labels on the Pushgateway with the registry metrics.
+func (pe *pushgatewayExporter) push(ctx context.Context) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	return pe.pusher.PushContext(ctx)
+}
+
+// Shutdown stops periodic pushes and pushes metrics for the last time.
+func (pe *pushgatewayExporter) Shutdown(ctx context.Context) error {
+	pe.stopOnce.Do(func() {
+		close(pe.stop)
+	})
+
+	select {
+	case <-pe.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return pe.push(ctx)
+}
@@ -0,0 +1,189 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testPushRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// testPushgateway records push requests in place of a Prometheus Pushgateway.
+type testPushgateway struct {
+	*httptest.Server
+
+	requests []testPushRequest
+	mu       sync.Mutex
+}
+
+func newTestPushgateway(t *testing.T) *testPushgateway {
+	t.Helper()
+
+	gateway := &testPushgateway{}
+	gateway.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		gateway.mu.Lock()
+		gateway.requests = append(gateway.requests, testPushRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Body:   string(body),
+		})
+		gateway.mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(gateway.Close)
+
+	return gateway
+}
+
+func (tp *testPushgateway) getRequests() []testPushRequest {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return append([]testPushRequest{}, tp.requests...)
+}
+
+func TestSetupOTelExporters_Pushgateway(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	t.Run("pushes metrics on flush and shutdown", func(t *testing.T) {
+		gateway := newTestPushgateway(t)
+		config := &OTLPConfig{
+			ServiceName:     "pushgateway-test",
+			MetricsExporter: OTELMetricsExporterPushgateway,
+			PushgatewayURL:  gateway.URL,
+			PushgatewayGroupingLabels: map[string]string{
+				"instance": "worker-1",
+				"env":      "test",
+			},
+			DisableGoMetrics: boolPtr(true),
+		}
+
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			config,
+			"v1.0.0",
+			logger,
+			WithGlobalRegistration(false),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+
+		if exporters.PrometheusHandler != nil {
+			t.Error("expected nil PrometheusHandler of the pushgateway exporter")
+		}
+
+		counter, err := exporters.Meter.Int64Counter("pushgateway_test_jobs")
+		if err != nil {
+			t.Fatalf("failed to create counter: %v", err)
+		}
+
+		counter.Add(context.Background(), 1)
+
+		if err := exporters.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("failed to flush exporters: %v", err)
+		}
+
+		if err := exporters.Shutdown(context.Background()); err != nil {
+			t.Fatalf("failed to shutdown exporters: %v", err)
+		}
+
+		requests := gateway.getRequests()
+		if len(requests) != 2 {
+			t.Fatalf("expected 2 push requests, got %d", len(requests))
+		}
+
+		for _, request := range requests {
+			if request.Method != http.MethodPut {
+				t.Errorf("expected method PUT, got %s", request.Method)
+			}
+
+			// the Pushgateway accepts grouping labels in any order.
+			for _, segment := range []string{"/job/pushgateway-test", "/env/test", "/instance/worker-1"} {
+				if !strings.HasPrefix(request.Path, "/metrics/job/") || !strings.Contains(request.Path, segment) {
+					t.Errorf("expected path to contain %s, got %s", segment, request.Path)
+				}
+			}
+
+			if !strings.Contains(request.Body, "pushgateway_test_jobs_total") {
+				t.Errorf("expected the pushed metrics to contain the test counter, got: %q", request.Body)
+			}
+		}
+	})
+
+	t.Run("pushes metrics periodically", func(t *testing.T) {
+		gateway := newTestPushgateway(t)
+		config := &OTLPConfig{
+			ServiceName:           "pushgateway-test",
+			MetricsExporter:       OTELMetricsExporterPushgateway,
+			PushgatewayURL:        gateway.URL,
+			PushgatewayJob:        "nightly",
+			MetricsExportInterval: uintPtr(10),
+			DisableGoMetrics:      boolPtr(true),
+		}
+
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			config,
+			"v1.0.0",
+			logger,
+			WithGlobalRegistration(false),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		deadline := time.Now().Add(5 * time.Second)
+		for len(gateway.getRequests()) == 0 {
+			if time.Now().After(deadline) {
+				t.Fatal("expected a periodic push request")
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if path := gateway.getRequests()[0].Path; path != "/metrics/job/nightly" {
+			t.Errorf("expected path /metrics/job/nightly, got %s", path)
+		}
+	})
+
+	t.Run("requires the Pushgateway URL", func(t *testing.T) {
+		config := &OTLPConfig{
+			ServiceName:     "pushgateway-test",
+			MetricsExporter: OTELMetricsExporterPushgateway,
+		}
+
+		_, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger, WithGlobalRegistration(false))
+		if !errors.Is(err, ErrPushgatewayURLRequired) {
+			t.Errorf("expected error %v, got: %v", ErrPushgatewayURLRequired, err)
+		}
+	})
+
+	t.Run("requires the job or service name", func(t *testing.T) {
+		config := &OTLPConfig{
+			MetricsExporter: OTELMetricsExporterPushgateway,
+			PushgatewayURL:  "http://localhost:9091",
+		}
+
+		_, err := SetupOTelExporters(context.Background(), config, "v1.0.0", logger, WithGlobalRegistration(false))
+		if !errors.Is(err, ErrPushgatewayJobRequired) {
+			t.Errorf("expected error %v, got: %v", ErrPushgatewayJobRequired, err)
+		}
+	})
+}
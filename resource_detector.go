@@ -0,0 +1,213 @@
+package gotel
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+)
+
+const (
+	procSelfCgroupPath             = "/proc/self/cgroup"
+	procSelfMountInfoPath          = "/proc/self/mountinfo"
+	k8sServiceAccountNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	deploymentEnvironmentEnv       = "DEPLOYMENT_ENVIRONMENT"
+)
+
+var (
+	// matches the container ID at the end of cgroup v1 paths, e.g.
+	// /docker/<id>, /kubepods/burstable/pod<uid>/<id> or /system.slice/docker-<id>.scope.
+	containerIDCgroupRegex = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+	// matches the container ID of cgroup v2 mounts, e.g. /var/lib/docker/containers/<id>/hostname.
+	// Paths of sandboxes/<id> are not matched because they hold the ID of the pod sandbox (pause) container.
+	containerIDMountInfoRegex = regexp.MustCompile(`/containers/([0-9a-f]{64})/`)
+
+	// serviceInstanceID is generated once, so all pipelines of the process share the same instance ID.
+	serviceInstanceID = sync.OnceValue(uuid.NewString)
+
+	// environment variables that are usually exposed by the Kubernetes downward API.
+	k8sDownwardAPIEnvAttributes = []struct {
+		Env string
+		Key attribute.Key
+	}{
+		{Env: "K8S_POD_NAME", Key: semconv.K8SPodNameKey},
+		{Env: "K8S_POD_UID", Key: semconv.K8SPodUIDKey},
+		{Env: "K8S_NAMESPACE_NAME", Key: semconv.K8SNamespaceNameKey},
+		{Env: "K8S_NODE_NAME", Key: semconv.K8SNodeNameKey},
+		{Env: "K8S_CONTAINER_NAME", Key: semconv.K8SContainerNameKey},
+	}
+
+	// maps GOARCH values to the host.arch semantic convention values.
+	hostArchValues = map[string]attribute.KeyValue{
+		"386":   semconv.HostArchX86,
+		"amd64": semconv.HostArchAMD64,
+		"arm":   semconv.HostArchARM32,
+		"arm64": semconv.HostArchARM64,
+		"ppc64": semconv.HostArchPPC64,
+		"s390x": semconv.HostArchS390x,
+	}
+)
+
+// detect resource attributes with the configured detectors in order.
+// Attributes of the env detector take precedence over other detectors.
+func detectResourceAttributes(detectorTypes []OTELResourceDetectorType) ([]attribute.KeyValue, error) {
+	attrs := []attribute.KeyValue{}
+
+	var envAttrs []attribute.KeyValue
+
+	for _, detectorType := range detectorTypes {
+		switch detectorType {
+		case OTELResourceDetectorNone:
+		case OTELResourceDetectorEnv:
+			envAttrs = resource.Environment().Attributes()
+		case OTELResourceDetectorHost:
+			attrs = append(attrs, detectHostAttributes()...)
+		case OTELResourceDetectorProcess:
+			attrs = append(attrs, detectProcessAttributes()...)
+		case OTELResourceDetectorContainer:
+			containerID := detectContainerID(procSelfCgroupPath, procSelfMountInfoPath)
+			if containerID != "" {
+				attrs = append(attrs, semconv.ContainerID(containerID))
+			}
+		case OTELResourceDetectorK8s:
+			attrs = append(attrs, detectK8sAttributes(k8sServiceAccountNamespacePath)...)
+		case OTELResourceDetectorService:
+			attrs = append(attrs, semconv.ServiceInstanceID(serviceInstanceID()))
+		case OTELResourceDetectorDeployment:
+			if env := os.Getenv(deploymentEnvironmentEnv); env != "" {
+				attrs = append(attrs, semconv.DeploymentEnvironmentNameKey.String(env))
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidOTELResourceDetectorType, detectorType)
+		}
+	}
+
+	return append(attrs, envAttrs...), nil
+}
+
+func detectHostAttributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.OSTypeKey.String(runtime.GOOS),
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		attrs = append(attrs, semconv.HostName(hostname))
+	}
+
+	if arch, ok := hostArchValues[runtime.GOARCH]; ok {
+		attrs = append(attrs, arch)
+	}
+
+	return attrs
+}
+
+func detectProcessAttributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.ProcessPID(os.Getpid()),
+		semconv.ProcessRuntimeName("go"),
+		semconv.ProcessRuntimeVersion(runtime.Version()),
+		semconv.ProcessRuntimeDescription(
+			fmt.Sprintf("go version %s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH),
+		),
+	}
+
+	if executablePath, err := os.Executable(); err == nil {
+		attrs = append(
+			attrs,
+			semconv.ProcessExecutablePath(executablePath),
+			semconv.ProcessExecutableName(filepath.Base(executablePath)),
+		)
+	}
+
+	return attrs
+}
+
+// detect the container ID from the cgroup v1 file, or the mountinfo file for cgroup v2.
+// Returns an empty string if the process does not run in a container.
+func detectContainerID(cgroupPath string, mountInfoPath string) string {
+	containerID := scanFileLines(cgroupPath, func(line string) string {
+		// each line has the format hierarchy-ID:controller-list:cgroup-path.
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) < 3 {
+			return ""
+		}
+
+		matches := containerIDCgroupRegex.FindStringSubmatch(parts[2])
+		if len(matches) < 2 {
+			return ""
+		}
+
+		return matches[1]
+	})
+	if containerID != "" {
+		return containerID
+	}
+
+	return scanFileLines(mountInfoPath, func(line string) string {
+		matches := containerIDMountInfoRegex.FindStringSubmatch(line)
+		if len(matches) < 2 {
+			return ""
+		}
+
+		return matches[1]
+	})
+}
+
+// detect Kubernetes attributes from downward API environment variables.
+// The namespace falls back to the service account namespace file.
+func detectK8sAttributes(namespacePath string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{}
+	hasNamespace := false
+
+	for _, item := range k8sDownwardAPIEnvAttributes {
+		value := os.Getenv(item.Env)
+		if value == "" {
+			continue
+		}
+
+		if item.Key == semconv.K8SNamespaceNameKey {
+			hasNamespace = true
+		}
+
+		attrs = append(attrs, item.Key.String(value))
+	}
+
+	if !hasNamespace {
+		rawNamespace, err := os.ReadFile(namespacePath)
+		if namespace := strings.TrimSpace(string(rawNamespace)); err == nil && namespace != "" {
+			attrs = append(attrs, semconv.K8SNamespaceName(namespace))
+		}
+	}
+
+	return attrs
+}
+
+// scan lines of the file and return the first non-empty result of the match function.
+// Returns an empty string if the file does not exist.
+func scanFileLines(filePath string, match func(line string) string) string {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return ""
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		if result := match(scanner.Text()); result != "" {
+			return result
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,201 @@
+package gotel
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Helper function to convert attributes to a map
+func attributesToMap(attrs []attribute.KeyValue) map[attribute.Key]string {
+	result := map[attribute.Key]string{}
+
+	for _, attr := range attrs {
+		result[attr.Key] = attr.Value.Emit()
+	}
+
+	return result
+}
+
+func TestDetectContainerID(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		CgroupFile string
+		MountInfo  string
+		Expected   string
+	}{
+		{
+			Name:       "cgroup v1 docker",
+			CgroupFile: "cgroup_v1_docker",
+			MountInfo:  "mountinfo_host",
+			Expected:   "3f4e5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a",
+		},
+		{
+			Name:       "cgroup v1 kubepods with containerd",
+			CgroupFile: "cgroup_v1_kubepods",
+			MountInfo:  "mountinfo_host",
+			Expected:   "0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b",
+		},
+		{
+			Name:       "cgroup v2 falls back to mountinfo",
+			CgroupFile: "cgroup_v2",
+			MountInfo:  "mountinfo_v2",
+			Expected:   "9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b",
+		},
+		{
+			Name:       "cgroup v2 containerd prefers the container over the sandbox",
+			CgroupFile: "cgroup_v2",
+			MountInfo:  "mountinfo_containerd",
+			Expected:   "7e1f2d3c4b5a69788796a5b4c3d2e1f0f1e2d3c4b5a69788796a5b4c3d2e1f00",
+		},
+		{
+			Name:       "cgroup v2 containerd ignores the sandbox",
+			CgroupFile: "cgroup_v2",
+			MountInfo:  "mountinfo_containerd_sandbox",
+		},
+		{
+			Name:       "not in container",
+			CgroupFile: "cgroup_v2",
+			MountInfo:  "mountinfo_host",
+		},
+		{
+			Name:       "files do not exist",
+			CgroupFile: "not_found",
+			MountInfo:  "not_found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			containerID := detectContainerID(
+				filepath.Join("testdata", "resource", tc.CgroupFile),
+				filepath.Join("testdata", "resource", tc.MountInfo),
+			)
+			if containerID != tc.Expected {
+				t.Errorf("expected container ID %q, got %q", tc.Expected, containerID)
+			}
+		})
+	}
+}
+
+func TestDetectK8sAttributes(t *testing.T) {
+	namespacePath := filepath.Join("testdata", "resource", "k8s_namespace")
+
+	t.Run("detects attributes from downward API env", func(t *testing.T) {
+		t.Setenv("K8S_POD_NAME", "api-7d9f8")
+		t.Setenv("K8S_POD_UID", "1c2d3e4f")
+		t.Setenv("K8S_NAMESPACE_NAME", "checkout")
+		t.Setenv("K8S_NODE_NAME", "node-1")
+		t.Setenv("K8S_CONTAINER_NAME", "api")
+
+		attrs := attributesToMap(detectK8sAttributes(namespacePath))
+		expected := map[attribute.Key]string{
+			"k8s.pod.name":       "api-7d9f8",
+			"k8s.pod.uid":        "1c2d3e4f",
+			"k8s.namespace.name": "checkout",
+			"k8s.node.name":      "node-1",
+			"k8s.container.name": "api",
+		}
+
+		for key, value := range expected {
+			if attrs[key] != value {
+				t.Errorf("expected %s to be %q, got %q", key, value, attrs[key])
+			}
+		}
+	})
+
+	t.Run("falls back to the service account namespace", func(t *testing.T) {
+		t.Setenv("K8S_POD_NAME", "api-7d9f8")
+		t.Setenv("K8S_NAMESPACE_NAME", "")
+
+		attrs := attributesToMap(detectK8sAttributes(namespacePath))
+		if attrs["k8s.namespace.name"] != "payments" {
+			t.Errorf("expected namespace payments, got %q", attrs["k8s.namespace.name"])
+		}
+	})
+
+	t.Run("outside Kubernetes", func(t *testing.T) {
+		for _, item := range k8sDownwardAPIEnvAttributes {
+			t.Setenv(item.Env, "")
+		}
+
+		attrs := detectK8sAttributes(filepath.Join("testdata", "resource", "not_found"))
+		if len(attrs) != 0 {
+			t.Errorf("expected no attributes, got %v", attrs)
+		}
+	})
+}
+
+func TestDetectResourceAttributes(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment.name=staging,custom.key=custom")
+	t.Setenv(deploymentEnvironmentEnv, "production")
+
+	t.Run("detects attributes of default detectors", func(t *testing.T) {
+		attrs, err := detectResourceAttributes(defaultResourceDetectors)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result := attributesToMap(attrs)
+
+		for _, key := range []attribute.Key{
+			"host.name", "os.type", "process.pid", "process.runtime.name", "service.instance.id", "custom.key",
+		} {
+			if result[key] == "" {
+				t.Errorf("expected attribute %s, got %v", key, result)
+			}
+		}
+
+		// duplicated keys keep the last value like resource.NewWithAttributes does.
+		if result["deployment.environment.name"] != "staging" {
+			t.Errorf("expected env attributes to take precedence, got %q", result["deployment.environment.name"])
+		}
+	})
+
+	t.Run("generates a stable service instance ID", func(t *testing.T) {
+		first, err := detectResourceAttributes([]OTELResourceDetectorType{OTELResourceDetectorService})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		second, err := detectResourceAttributes([]OTELResourceDetectorType{OTELResourceDetectorService})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(first) != 1 || first[0] != second[0] {
+			t.Errorf("expected the same service instance ID, got %v and %v", first, second)
+		}
+	})
+
+	t.Run("detects deployment environment", func(t *testing.T) {
+		attrs, err := detectResourceAttributes([]OTELResourceDetectorType{OTELResourceDetectorDeployment})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result := attributesToMap(attrs); result["deployment.environment.name"] != "production" {
+			t.Errorf("expected deployment environment production, got %v", result)
+		}
+	})
+
+	t.Run("none disables detection", func(t *testing.T) {
+		attrs, err := detectResourceAttributes([]OTELResourceDetectorType{OTELResourceDetectorNone})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(attrs) != 0 {
+			t.Errorf("expected no attributes, got %v", attrs)
+		}
+	})
+
+	t.Run("invalid detector", func(t *testing.T) {
+		_, err := detectResourceAttributes([]OTELResourceDetectorType{"gcp"})
+		if !errors.Is(err, ErrInvalidOTELResourceDetectorType) {
+			t.Errorf("expected error %v, got: %v", ErrInvalidOTELResourceDetectorType, err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package gotel
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+type setupOptions struct {
+	spanProcessors     []trace.SpanProcessor
+	spanExporters      []trace.SpanExporter
+	idGenerator        trace.IDGenerator
+	metricReaders      []metric.Reader
+	metricExporters    []metric.Exporter
+	views              []metric.View
+	logProcessors      []log.Processor
+	logExporters       []log.Exporter
+	resources          []*resource.Resource
+	globalRegistration bool
+}
+
+func newSetupOptions(options []SetupOption) *setupOptions {
+	result := &setupOptions{
+		globalRegistration: true,
+	}
+
+	for _, opt := range options {
+		opt(result)
+	}
+
+	return result
+}
+
+// SetupOption abstracts a function to apply options to [SetupOTelExporters].
+type SetupOption func(*setupOptions)
+
+// WithSpanProcessor registers a custom span processor to the tracer provider
+// in addition to the exporter of the configuration.
+func WithSpanProcessor(processor trace.SpanProcessor) SetupOption {
+	return func(so *setupOptions) {
+		so.spanProcessors = append(so.spanProcessors, processor)
+	}
+}
+
+// WithSpanExporter registers a custom span exporter to the tracer provider.
+// The exporter is wrapped by a batch span processor with the batch options of the configuration.
+func WithSpanExporter(exporter trace.SpanExporter) SetupOption {
+	return func(so *setupOptions) {
+		so.spanExporters = append(so.spanExporters, exporter)
+	}
+}
+
+// WithIDGenerator sets a custom generator of trace and span IDs.
+func WithIDGenerator(generator trace.IDGenerator) SetupOption {
+	return func(so *setupOptions) {
+		so.idGenerator = generator
+	}
+}
+
+// WithMetricReader registers a custom metric reader to the meter provider
+// in addition to the exporter of the configuration.
+func WithMetricReader(reader metric.Reader) SetupOption {
+	return func(so *setupOptions) {
+		so.metricReaders = append(so.metricReaders, reader)
+	}
+}
+
+// WithMetricExporter registers a custom metric exporter to the meter provider.
+// The exporter is wrapped by a periodic reader with the export interval and timeout of the configuration.
+func WithMetricExporter(exporter metric.Exporter) SetupOption {
+	return func(so *setupOptions) {
+		so.metricExporters = append(so.metricExporters, exporter)
+	}
+}
+
+// WithView registers views that customize the metric streams of all readers.
+func WithView(views ...metric.View) SetupOption {
+	return func(so *setupOptions) {
+		so.views = append(so.views, views...)
+	}
+}
+
+// WithLogProcessor registers a custom log processor to the logger provider
+// in addition to the exporter of the configuration.
+func WithLogProcessor(processor log.Processor) SetupOption {
+	return func(so *setupOptions) {
+		so.logProcessors = append(so.logProcessors, processor)
+	}
+}
+
+// WithLogExporter registers a custom log exporter to the logger provider.
+// The exporter is wrapped by a batch log processor with the batch options of the configuration.
+func WithLogExporter(exporter log.Exporter) SetupOption {
+	return func(so *setupOptions) {
+		so.logExporters = append(so.logExporters, exporter)
+	}
+}
+
+// WithResource merges the resource into the resource that is created from the configuration.
+// Attributes of the custom resource take precedence.
+func WithResource(res *resource.Resource) SetupOption {
+	return func(so *setupOptions) {
+		so.resources = append(so.resources, res)
+	}
+}
+
+// WithGlobalRegistration sets whether the providers, propagator and error handler logger
+// are registered as OpenTelemetry globals. Default is true.
+// Disable it to run multiple isolated pipelines in the same process,
+// and use the providers and propagator of [OTelExporters] instead.
+func WithGlobalRegistration(enabled bool) SetupOption {
+	return func(so *setupOptions) {
+		so.globalRegistration = enabled
+	}
+}
+
+// merge resources in order. The schema URL of the base resource is dropped
+// if it conflicts with the one of a custom resource.
+func mergeResources(base *resource.Resource, resources []*resource.Resource) (*resource.Resource, error) {
+	result := base
+
+	for _, res := range resources {
+		merged, err := resource.Merge(result, res)
+		if err != nil && !errors.Is(err, resource.ErrSchemaURLConflict) {
+			return nil, err
+		}
+
+		result = merged
+	}
+
+	return result, nil
+}
@@ -0,0 +1,420 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	logapi "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	traceapi "go.opentelemetry.io/otel/trace"
+)
+
+// fixedIDGenerator generates constant trace and span IDs for testing
+type fixedIDGenerator struct{}
+
+func (fixedIDGenerator) NewIDs(context.Context) (traceapi.TraceID, traceapi.SpanID) {
+	return traceapi.TraceID{0x01}, traceapi.SpanID{0x02}
+}
+
+func (fixedIDGenerator) NewSpanID(context.Context, traceapi.TraceID) traceapi.SpanID {
+	return traceapi.SpanID{0x03}
+}
+
+// countingSpanExporter counts exported spans for testing
+type countingSpanExporter struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (cse *countingSpanExporter) ExportSpans(_ context.Context, spans []trace.ReadOnlySpan) error {
+	cse.mu.Lock()
+	defer cse.mu.Unlock()
+
+	cse.count += len(spans)
+
+	return nil
+}
+
+func (cse *countingSpanExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// recordingLogProcessor records log bodies for testing
+type recordingLogProcessor struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (rlp *recordingLogProcessor) OnEmit(_ context.Context, record *log.Record) error {
+	rlp.mu.Lock()
+	defer rlp.mu.Unlock()
+
+	rlp.bodies = append(rlp.bodies, record.Body().AsString())
+
+	return nil
+}
+
+func (rlp *recordingLogProcessor) Enabled(context.Context, log.EnabledParameters) bool {
+	return true
+}
+
+func (rlp *recordingLogProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (rlp *recordingLogProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func TestSetupOTelExporters_SetupOptions(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	spanRecorder := tracetest.NewSpanRecorder()
+	spanExporter := &countingSpanExporter{}
+	metricReader := metric.NewManualReader()
+	logProcessor := &recordingLogProcessor{}
+
+	config := &OTLPConfig{
+		ServiceName:    "setup-options-test",
+		TracesExporter: OTELTracesExporterNone,
+		// collect the test metric only.
+		DisableGoMetrics: boolPtr(true),
+	}
+
+	exporters, err := SetupOTelExporters(
+		context.Background(),
+		config,
+		"v1.0.0",
+		logger,
+		WithSpanProcessor(spanRecorder),
+		WithSpanExporter(spanExporter),
+		WithIDGenerator(fixedIDGenerator{}),
+		WithMetricReader(metricReader),
+		WithView(metric.NewView(
+			metric.Instrument{Name: "setup.options.requests"},
+			metric.Stream{Name: "setup.options.renamed"},
+		)),
+		WithLogProcessor(logProcessor),
+		WithResource(resource.NewSchemaless(
+			attribute.String("deployment.environment.name", "test"),
+			attribute.String("service.name", "overridden-service"),
+		)),
+	)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	ctx, span := exporters.Tracer.Start(context.Background(), "options-span")
+
+	counter, err := exporters.Meter.Int64Counter("setup.options.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+
+	counter.Add(ctx, 1)
+	exporters.Logger.InfoContext(ctx, "hello setup options")
+	span.End()
+
+	var metrics metricdata.ResourceMetrics
+	if err := metricReader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	if err := exporters.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shutdown exporters: %v", err)
+	}
+
+	t.Run("registers span processor and ID generator", func(t *testing.T) {
+		spans := spanRecorder.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(spans))
+		}
+
+		if spans[0].SpanContext().TraceID() != (traceapi.TraceID{0x01}) {
+			t.Errorf("expected the custom trace ID, got %s", spans[0].SpanContext().TraceID())
+		}
+
+		attrs := map[attribute.Key]string{}
+		for _, attr := range spans[0].Resource().Attributes() {
+			attrs[attr.Key] = attr.Value.Emit()
+		}
+
+		if attrs["deployment.environment.name"] != "test" {
+			t.Errorf("expected the custom resource attribute, got %v", attrs)
+		}
+
+		if attrs["service.name"] != "overridden-service" {
+			t.Errorf("expected the custom resource to take precedence, got %s", attrs["service.name"])
+		}
+
+		if attrs["service.version"] != "v1.0.0" {
+			t.Errorf("expected the config resource attributes to be kept, got %v", attrs)
+		}
+	})
+
+	t.Run("registers span exporter", func(t *testing.T) {
+		spanExporter.mu.Lock()
+		defer spanExporter.mu.Unlock()
+
+		if spanExporter.count != 1 {
+			t.Errorf("expected 1 span, got %d", spanExporter.count)
+		}
+	})
+
+	t.Run("registers metric reader and view", func(t *testing.T) {
+		if len(metrics.ScopeMetrics) != 1 || len(metrics.ScopeMetrics[0].Metrics) != 1 {
+			t.Fatalf("expected 1 metric, got %v", metrics.ScopeMetrics)
+		}
+
+		if name := metrics.ScopeMetrics[0].Metrics[0].Name; name != "setup.options.renamed" {
+			t.Errorf("expected the metric to be renamed by the view, got %s", name)
+		}
+	})
+
+	t.Run("registers log processor", func(t *testing.T) {
+		logProcessor.mu.Lock()
+		defer logProcessor.mu.Unlock()
+
+		if len(logProcessor.bodies) != 1 || logProcessor.bodies[0] != "hello setup options" {
+			t.Errorf("expected the log record to be processed, got %v", logProcessor.bodies)
+		}
+	})
+}
+
+// setTestOTelGlobals replaces the OpenTelemetry globals with new providers
+// and restores the current ones when the test finishes
+func setTestOTelGlobals(t *testing.T) (*trace.TracerProvider, *metric.MeterProvider, *log.LoggerProvider) {
+	t.Helper()
+
+	currentTracerProvider := otel.GetTracerProvider()
+	currentMeterProvider := otel.GetMeterProvider()
+	currentLoggerProvider := global.GetLoggerProvider()
+	currentPropagator := otel.GetTextMapPropagator()
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(currentTracerProvider)
+		otel.SetMeterProvider(currentMeterProvider)
+		global.SetLoggerProvider(currentLoggerProvider)
+		otel.SetTextMapPropagator(currentPropagator)
+	})
+
+	tracerProvider := trace.NewTracerProvider()
+	meterProvider := metric.NewMeterProvider()
+	loggerProvider := log.NewLoggerProvider()
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	global.SetLoggerProvider(loggerProvider)
+	otel.SetTextMapPropagator(propagation.Baggage{})
+
+	return tracerProvider, meterProvider, loggerProvider
+}
+
+func assertTestOTelGlobals(
+	t *testing.T,
+	tracerProvider *trace.TracerProvider,
+	meterProvider *metric.MeterProvider,
+	loggerProvider *log.LoggerProvider,
+) {
+	t.Helper()
+
+	if otel.GetTracerProvider() != traceapi.TracerProvider(tracerProvider) {
+		t.Error("expected the global tracer provider to be unchanged")
+	}
+
+	if otel.GetMeterProvider() != metricapi.MeterProvider(meterProvider) {
+		t.Error("expected the global meter provider to be unchanged")
+	}
+
+	if global.GetLoggerProvider() != logapi.LoggerProvider(loggerProvider) {
+		t.Error("expected the global logger provider to be unchanged")
+	}
+
+	if otel.GetTextMapPropagator() != propagation.TextMapPropagator(propagation.Baggage{}) {
+		t.Error("expected the global propagator to be unchanged")
+	}
+}
+
+func TestSetupOTelExporters_WithoutGlobalRegistration(t *testing.T) {
+	tracerProvider, meterProvider, loggerProvider := setTestOTelGlobals(t)
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	spanRecorders := []*tracetest.SpanRecorder{tracetest.NewSpanRecorder(), tracetest.NewSpanRecorder()}
+	pipelines := make([]*OTelExporters, len(spanRecorders))
+
+	for i, spanRecorder := range spanRecorders {
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			&OTLPConfig{
+				ServiceName:    "isolated-pipeline",
+				TracesExporter: OTELTracesExporterNone,
+				Propagators:    []OTELPropagatorType{OTELPropagatorB3},
+			},
+			"v1.0.0",
+			logger,
+			WithSpanProcessor(spanRecorder),
+			WithGlobalRegistration(false),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+
+		pipelines[i] = exporters
+	}
+
+	assertTestOTelGlobals(t, tracerProvider, meterProvider, loggerProvider)
+
+	for i, exporters := range pipelines {
+		if exporters.TracerProvider == nil || exporters.MeterProvider == nil ||
+			exporters.LoggerProvider == nil || exporters.Propagator == nil {
+			t.Fatalf("expected the providers and propagator of pipeline %d to be set", i)
+		}
+
+		if fields := exporters.Propagator.Fields(); len(fields) != 1 || fields[0] != "b3" {
+			t.Errorf("expected the b3 propagator, got %v", fields)
+		}
+
+		_, span := exporters.Tracer.Start(context.Background(), "isolated-span")
+		span.End()
+	}
+
+	for i, exporters := range pipelines {
+		if err := exporters.Shutdown(context.Background()); err != nil {
+			t.Fatalf("failed to shutdown exporters: %v", err)
+		}
+
+		if spans := spanRecorders[i].Ended(); len(spans) != 1 {
+			t.Errorf("expected 1 span in pipeline %d, got %d", i, len(spans))
+		}
+	}
+
+	assertTestOTelGlobals(t, tracerProvider, meterProvider, loggerProvider)
+}
+
+func TestSetupOTelExporters_RestoresGlobals(t *testing.T) {
+	tracerProvider, meterProvider, loggerProvider := setTestOTelGlobals(t)
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	exporters, err := SetupOTelExporters(
+		context.Background(),
+		&OTLPConfig{
+			ServiceName:    "global-pipeline",
+			TracesExporter: OTELTracesExporterNone,
+		},
+		"v1.0.0",
+		logger,
+	)
+	if err != nil {
+		t.Fatalf("failed to setup exporters: %v", err)
+	}
+
+	if otel.GetTracerProvider() != traceapi.TracerProvider(exporters.TracerProvider) {
+		t.Error("expected the tracer provider to be registered globally")
+	}
+
+	if otel.GetMeterProvider() != metricapi.MeterProvider(exporters.MeterProvider) {
+		t.Error("expected the meter provider to be registered globally")
+	}
+
+	if global.GetLoggerProvider() != logapi.LoggerProvider(exporters.LoggerProvider) {
+		t.Error("expected the logger provider to be registered globally")
+	}
+
+	if err := exporters.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shutdown exporters: %v", err)
+	}
+
+	assertTestOTelGlobals(t, tracerProvider, meterProvider, loggerProvider)
+}
+
+// shutdownSpanProcessor records whether the span processor is shut down for testing
+type shutdownSpanProcessor struct {
+	tracetest.SpanRecorder
+
+	shutdown bool
+}
+
+func (ssp *shutdownSpanProcessor) Shutdown(ctx context.Context) error {
+	ssp.shutdown = true
+
+	return ssp.SpanRecorder.Shutdown(ctx)
+}
+
+func TestSetupOTelExporters_ShutsDownOnError(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	t.Run("shuts down the created providers", func(t *testing.T) {
+		tracerProvider, meterProvider, loggerProvider := setTestOTelGlobals(t)
+		spanProcessor := &shutdownSpanProcessor{}
+		metricReader := metric.NewManualReader()
+
+		_, err := SetupOTelExporters(
+			context.Background(),
+			&OTLPConfig{
+				ServiceName:    "failed-pipeline",
+				TracesExporter: OTELTracesExporterNone,
+				LogsExporter:   "syslog",
+			},
+			"v1.0.0",
+			logger,
+			WithSpanProcessor(spanProcessor),
+			WithMetricReader(metricReader),
+		)
+		if !errors.Is(err, ErrInvalidOTELLogsExporterType) {
+			t.Fatalf("expected error %v, got: %v", ErrInvalidOTELLogsExporterType, err)
+		}
+
+		if !spanProcessor.shutdown {
+			t.Error("expected the span processor to be shut down")
+		}
+
+		err = metricReader.Collect(context.Background(), &metricdata.ResourceMetrics{})
+		if !errors.Is(err, metric.ErrReaderShutdown) {
+			t.Errorf("expected the metric reader to be shut down, got: %v", err)
+		}
+
+		assertTestOTelGlobals(t, tracerProvider, meterProvider, loggerProvider)
+	})
+
+	t.Run("restores globals if the Prometheus server fails to start", func(t *testing.T) {
+		tracerProvider, meterProvider, loggerProvider := setTestOTelGlobals(t)
+
+		listener, err := net.Listen("tcp", ":0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		defer listener.Close()
+
+		port := uint(listener.Addr().(*net.TCPAddr).Port)
+
+		_, err = SetupOTelExporters(
+			context.Background(),
+			&OTLPConfig{
+				ServiceName:     "failed-pipeline",
+				TracesExporter:  OTELTracesExporterNone,
+				MetricsExporter: OTELMetricsExporterPrometheus,
+				PrometheusPort:  &port,
+			},
+			"v1.0.0",
+			logger,
+		)
+		if err == nil {
+			t.Fatal("expected an error of the used Prometheus port")
+		}
+
+		assertTestOTelGlobals(t, tracerProvider, meterProvider, loggerProvider)
+	})
+}
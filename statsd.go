@@ -0,0 +1,316 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	defaultStatsdAddress = "localhost:8125"
+	// maximum size of UDP packets that fit in the common MTU of 1500 bytes, the same as DogStatsD clients.
+	statsdMaxPacketSize = 1432
+
+	statsdTypeCounter = "c"
+	statsdTypeGauge   = "g"
+)
+
+var (
+	errStatsdExporterShutdown = errors.New("statsd exporter is shut down")
+
+	// replace characters that are reserved by the line format.
+	statsdNameReplacer     = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_")
+	statsdTagKeyReplacer   = strings.NewReplacer(":", "_", "|", "_", ",", "_", "#", "_", "\n", "_")
+	statsdTagValueReplacer = strings.NewReplacer("|", "_", ",", "_", "\n", "_")
+)
+
+// statsdExporter sends metrics to a StatsD agent in StatsD or DogStatsD lines over UDP.
+// Counters are sent as deltas, up-down counters and gauges as gauges of the current value,
+// and histograms as the .count and .sum counters and the .min and .max gauges of every interval.
+type statsdExporter struct {
+	conn     net.Conn
+	flavor   StatsdFlavor
+	shutdown atomic.Bool
+}
+
+// create the StatsD exporter that sends lines to the configured address.
+func newStatsdExporter(config *OTLPConfig) (*statsdExporter, error) {
+	conn, err := net.Dial("udp", config.GetStatsdAddress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the StatsD agent: %w", err)
+	}
+
+	return &statsdExporter{
+		conn:   conn,
+		flavor: config.GetStatsdFlavor(),
+	}, nil
+}
+
+// Temporality returns the delta temporality for counters and histograms,
+// and the cumulative temporality for up-down counters and gauges.
+func (se *statsdExporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case metric.InstrumentKindUpDownCounter,
+		metric.InstrumentKindObservableUpDownCounter,
+		metric.InstrumentKindGauge,
+		metric.InstrumentKindObservableGauge:
+		return metricdata.CumulativeTemporality
+	default:
+		return metricdata.DeltaTemporality
+	}
+}
+
+// Aggregation returns the default aggregation of the instrument kind.
+func (se *statsdExporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return metric.DefaultAggregationSelector(kind)
+}
+
+// Export sends the resource metrics in packets of newline-separated lines.
+func (se *statsdExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if se.shutdown.Load() {
+		return errStatsdExporterShutdown
+	}
+
+	lines := []string{}
+
+	for _, scopeMetrics := range rm.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			lines = se.appendMetric(lines, m)
+		}
+	}
+
+	errs := []error{}
+
+	for _, packet := range packStatsdLines(lines, statsdMaxPacketSize) {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		_, err = se.conn.Write(packet)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ForceFlush does nothing because the exporter does not buffer metrics.
+func (se *statsdExporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown stops exporting metrics and closes the connection.
+func (se *statsdExporter) Shutdown(_ context.Context) error {
+	if !se.shutdown.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	return se.conn.Close()
+}
+
+// append lines of data points of the metric. Summaries are not supported and dropped.
+func (se *statsdExporter) appendMetric(lines []string, m metricdata.Metrics) []string {
+	name := statsdNameReplacer.Replace(m.Name)
+
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		return se.appendSum(lines, name, data.IsMonotonic, toStatsdPoints(data.DataPoints))
+	case metricdata.Sum[float64]:
+		return se.appendSum(lines, name, data.IsMonotonic, toStatsdPoints(data.DataPoints))
+	case metricdata.Gauge[int64]:
+		return se.appendSum(lines, name, false, toStatsdPoints(data.DataPoints))
+	case metricdata.Gauge[float64]:
+		return se.appendSum(lines, name, false, toStatsdPoints(data.DataPoints))
+	case metricdata.Histogram[int64]:
+		return se.appendHistograms(lines, name, toStatsdHistograms(data.DataPoints))
+	case metricdata.Histogram[float64]:
+		return se.appendHistograms(lines, name, toStatsdHistograms(data.DataPoints))
+	case metricdata.ExponentialHistogram[int64]:
+		return se.appendHistograms(lines, name, toStatsdExponentialHistograms(data.DataPoints))
+	case metricdata.ExponentialHistogram[float64]:
+		return se.appendHistograms(lines, name, toStatsdExponentialHistograms(data.DataPoints))
+	default:
+		return lines
+	}
+}
+
+// append monotonic sums as counters and other values as gauges.
+func (se *statsdExporter) appendSum(lines []string, name string, isMonotonic bool, points []statsdPoint) []string {
+	for _, point := range points {
+		tags := se.formatTags(point.attributes)
+
+		if isMonotonic {
+			lines = append(lines, formatStatsdLine(name, point.value, statsdTypeCounter, tags))
+
+			continue
+		}
+
+		lines = se.appendGauge(lines, name, point.value, tags)
+	}
+
+	return lines
+}
+
+func (se *statsdExporter) appendHistograms(lines []string, name string, histograms []statsdHistogram) []string {
+	for _, histogram := range histograms {
+		tags := se.formatTags(histogram.attributes)
+
+		lines = append(
+			lines,
+			formatStatsdLine(name+".count", float64(histogram.count), statsdTypeCounter, tags),
+			formatStatsdLine(name+".sum", histogram.sum, statsdTypeCounter, tags),
+		)
+
+		if histogram.min != nil {
+			lines = se.appendGauge(lines, name+".min", *histogram.min, tags)
+		}
+
+		if histogram.max != nil {
+			lines = se.appendGauge(lines, name+".max", *histogram.max, tags)
+		}
+	}
+
+	return lines
+}
+
+// append the gauge line. A signed value changes the gauge by the value in the plain StatsD format,
+// so negative values are sent after resetting the gauge to zero.
+func (se *statsdExporter) appendGauge(lines []string, name string, value float64, tags string) []string {
+	if value < 0 && se.flavor == StatsdFlavorStatsd {
+		lines = append(lines, formatStatsdLine(name, 0, statsdTypeGauge, tags))
+	}
+
+	return append(lines, formatStatsdLine(name, value, statsdTypeGauge, tags))
+}
+
+// format attributes as the tags section of DogStatsD lines.
+// Returns an empty string in the plain StatsD format that does not support tags.
+func (se *statsdExporter) formatTags(attrs attribute.Set) string {
+	if se.flavor != StatsdFlavorDogStatsd || attrs.Len() == 0 {
+		return ""
+	}
+
+	tags := make([]string, 0, attrs.Len())
+	iter := attrs.Iter()
+
+	for iter.Next() {
+		kv := iter.Attribute()
+		tags = append(
+			tags,
+			statsdTagKeyReplacer.Replace(string(kv.Key))+":"+statsdTagValueReplacer.Replace(kv.Value.Emit()),
+		)
+	}
+
+	return "|#" + strings.Join(tags, ",")
+}
+
+func formatStatsdLine(name string, value float64, metricType string, tags string) string {
+	return name + ":" + strconv.FormatFloat(value, 'f', -1, 64) + "|" + metricType + tags
+}
+
+// join lines into packets that do not exceed the maximum size, except lines that are longer than the size.
+func packStatsdLines(lines []string, maxSize int) [][]byte {
+	packets := [][]byte{}
+
+	var packet []byte
+
+	for _, line := range lines {
+		if len(packet) > 0 && len(packet)+1+len(line) > maxSize {
+			packets = append(packets, packet)
+			packet = nil
+		}
+
+		if len(packet) > 0 {
+			packet = append(packet, '\n')
+		}
+
+		packet = append(packet, line...)
+	}
+
+	if len(packet) > 0 {
+		packets = append(packets, packet)
+	}
+
+	return packets
+}
+
+type statsdPoint struct {
+	attributes attribute.Set
+	value      float64
+}
+
+func toStatsdPoints[N int64 | float64](dataPoints []metricdata.DataPoint[N]) []statsdPoint {
+	points := make([]statsdPoint, len(dataPoints))
+
+	for i, dp := range dataPoints {
+		points[i] = statsdPoint{
+			attributes: dp.Attributes,
+			value:      float64(dp.Value),
+		}
+	}
+
+	return points
+}
+
+type statsdHistogram struct {
+	attributes attribute.Set
+	count      uint64
+	sum        float64
+	min        *float64
+	max        *float64
+}
+
+func toStatsdHistograms[N int64 | float64](dataPoints []metricdata.HistogramDataPoint[N]) []statsdHistogram {
+	histograms := make([]statsdHistogram, len(dataPoints))
+
+	for i, dp := range dataPoints {
+		histograms[i] = statsdHistogram{
+			attributes: dp.Attributes,
+			count:      dp.Count,
+			sum:        float64(dp.Sum),
+			min:        getStatsdExtremaValue(dp.Min),
+			max:        getStatsdExtremaValue(dp.Max),
+		}
+	}
+
+	return histograms
+}
+
+func toStatsdExponentialHistograms[N int64 | float64](
+	dataPoints []metricdata.ExponentialHistogramDataPoint[N],
+) []statsdHistogram {
+	histograms := make([]statsdHistogram, len(dataPoints))
+
+	for i, dp := range dataPoints {
+		histograms[i] = statsdHistogram{
+			attributes: dp.Attributes,
+			count:      dp.Count,
+			sum:        float64(dp.Sum),
+			min:        getStatsdExtremaValue(dp.Min),
+			max:        getStatsdExtremaValue(dp.Max),
+		}
+	}
+
+	return histograms
+}
+
+func getStatsdExtremaValue[N int64 | float64](extrema metricdata.Extrema[N]) *float64 {
+	value, ok := extrema.Value()
+	if !ok {
+		return nil
+	}
+
+	result := float64(value)
+
+	return &result
+}
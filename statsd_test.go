@@ -0,0 +1,299 @@
+package gotel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricapi "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// listen on a local UDP port in place of a StatsD agent.
+func newTestStatsdListener(t *testing.T) net.PacketConn {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen on UDP: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return conn
+}
+
+// read lines from the listener until all the expected lines are received.
+func waitTestStatsdLines(t *testing.T, conn net.PacketConn, expected []string) {
+	t.Helper()
+
+	received := []string{}
+	buf := make([]byte, statsdMaxPacketSize)
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+
+	for {
+		missing := slices.DeleteFunc(slices.Clone(expected), func(line string) bool {
+			return slices.Contains(received, line)
+		})
+		if len(missing) == 0 {
+			return
+		}
+
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("expected lines %v, got %v: %v", missing, received, err)
+		}
+
+		received = append(received, strings.Split(string(buf[:n]), "\n")...)
+	}
+}
+
+func TestSetupOTelExporters_Statsd(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	t.Run("sends DogStatsD lines on flush", func(t *testing.T) {
+		listener := newTestStatsdListener(t)
+		config := &OTLPConfig{
+			ServiceName:      "statsd-test",
+			MetricsExporter:  OTELMetricsExporterStatsd,
+			StatsdAddress:    listener.LocalAddr().String(),
+			DisableGoMetrics: boolPtr(true),
+		}
+
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			config,
+			"v1.0.0",
+			logger,
+			WithGlobalRegistration(false),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		counter, err := exporters.Meter.Int64Counter("statsd_test.requests")
+		if err != nil {
+			t.Fatalf("failed to create counter: %v", err)
+		}
+
+		counter.Add(context.Background(), 2, metricapi.WithAttributes(attribute.String("route", "/users")))
+
+		upDownCounter, err := exporters.Meter.Int64UpDownCounter("statsd_test.active")
+		if err != nil {
+			t.Fatalf("failed to create up-down counter: %v", err)
+		}
+
+		upDownCounter.Add(context.Background(), -3)
+
+		histogram, err := exporters.Meter.Float64Histogram("statsd_test.duration")
+		if err != nil {
+			t.Fatalf("failed to create histogram: %v", err)
+		}
+
+		histogram.Record(context.Background(), 1.5)
+		histogram.Record(context.Background(), 7)
+
+		gauge, err := exporters.Meter.Int64Gauge("statsd_test.temperature")
+		if err != nil {
+			t.Fatalf("failed to create gauge: %v", err)
+		}
+
+		gauge.Record(context.Background(), 5)
+
+		if err := exporters.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("failed to flush exporters: %v", err)
+		}
+
+		waitTestStatsdLines(t, listener, []string{
+			"statsd_test.requests:2|c|#route:/users",
+			"statsd_test.active:-3|g",
+			"statsd_test.duration.count:2|c",
+			"statsd_test.duration.sum:8.5|c",
+			"statsd_test.duration.min:1.5|g",
+			"statsd_test.duration.max:7|g",
+			"statsd_test.temperature:5|g",
+		})
+	})
+
+	t.Run("sends lines on every flush interval", func(t *testing.T) {
+		listener := newTestStatsdListener(t)
+		config := &OTLPConfig{
+			ServiceName:           "statsd-test",
+			MetricsExporter:       OTELMetricsExporterStatsd,
+			StatsdAddress:         listener.LocalAddr().String(),
+			StatsdFlavor:          StatsdFlavorStatsd,
+			StatsdFlushInterval:   uintPtr(10),
+			MetricsExportInterval: uintPtr(60000),
+			DisableGoMetrics:      boolPtr(true),
+		}
+
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			config,
+			"v1.0.0",
+			logger,
+			WithGlobalRegistration(false),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		counter, err := exporters.Meter.Int64Counter("statsd_test.jobs")
+		if err != nil {
+			t.Fatalf("failed to create counter: %v", err)
+		}
+
+		counter.Add(context.Background(), 1, metricapi.WithAttributes(attribute.String("queue", "default")))
+
+		waitTestStatsdLines(t, listener, []string{"statsd_test.jobs:1|c"})
+	})
+
+	t.Run("applies the flush interval to the StatsD exporter only", func(t *testing.T) {
+		listener := newTestStatsdListener(t)
+		customExporter := &testMetricExporter{}
+		config := &OTLPConfig{
+			ServiceName:           "statsd-test",
+			MetricsExporter:       OTELMetricsExporterStatsd,
+			StatsdAddress:         listener.LocalAddr().String(),
+			StatsdFlushInterval:   uintPtr(10),
+			MetricsExportInterval: uintPtr(60000),
+			DisableGoMetrics:      boolPtr(true),
+		}
+
+		exporters, err := SetupOTelExporters(
+			context.Background(),
+			config,
+			"v1.0.0",
+			logger,
+			WithGlobalRegistration(false),
+			WithMetricExporter(customExporter),
+		)
+		if err != nil {
+			t.Fatalf("failed to setup exporters: %v", err)
+		}
+		defer exporters.Shutdown(context.Background())
+
+		counter, err := exporters.Meter.Int64Counter("statsd_test.retries")
+		if err != nil {
+			t.Fatalf("failed to create counter: %v", err)
+		}
+
+		counter.Add(context.Background(), 1)
+
+		waitTestStatsdLines(t, listener, []string{"statsd_test.retries:1|c"})
+		time.Sleep(100 * time.Millisecond)
+
+		customExporter.mu.Lock()
+		defer customExporter.mu.Unlock()
+
+		if len(customExporter.names) > 0 {
+			t.Errorf("expected no periodic export of the custom exporter, got metrics %v", customExporter.names)
+		}
+	})
+}
+
+func TestStatsdExporter_AppendMetric(t *testing.T) {
+	attrs := attribute.NewSet(attribute.String("http.route", "/users|list"), attribute.Int("code", 200))
+	upDownSum := metricdata.Metrics{
+		Name: "queue size",
+		Data: metricdata.Sum[int64]{
+			IsMonotonic: false,
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: -2}},
+		},
+	}
+
+	testCases := []struct {
+		Name     string
+		Flavor   StatsdFlavor
+		Metric   metricdata.Metrics
+		Expected []string
+	}{
+		{
+			Name:     "sends sanitized tags in the DogStatsD format",
+			Flavor:   StatsdFlavorDogStatsd,
+			Metric:   upDownSum,
+			Expected: []string{"queue_size:-2|g|#code:200,http.route:/users_list"},
+		},
+		{
+			Name:     "resets negative gauges without tags in the StatsD format",
+			Flavor:   StatsdFlavorStatsd,
+			Metric:   upDownSum,
+			Expected: []string{"queue_size:0|g", "queue_size:-2|g"},
+		},
+		{
+			Name:   "drops summaries",
+			Flavor: StatsdFlavorDogStatsd,
+			Metric: metricdata.Metrics{
+				Name: "latency",
+				Data: metricdata.Summary{
+					DataPoints: []metricdata.SummaryDataPoint{{Count: 1, Sum: 2}},
+				},
+			},
+			Expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			exporter := &statsdExporter{flavor: tc.Flavor}
+
+			lines := exporter.appendMetric([]string{}, tc.Metric)
+			if !slices.Equal(lines, tc.Expected) {
+				t.Errorf("expected lines %v, got %v", tc.Expected, lines)
+			}
+		})
+	}
+}
+
+func TestPackStatsdLines(t *testing.T) {
+	lines := []string{"a:1|c", "b:2|c", "c:3|c", strings.Repeat("d", 20) + ":4|c"}
+
+	packets := packStatsdLines(lines, 12)
+
+	expected := []string{"a:1|c\nb:2|c", "c:3|c", strings.Repeat("d", 20) + ":4|c"}
+	if len(packets) != len(expected) {
+		t.Fatalf("expected %d packets, got %d: %q", len(expected), len(packets), packets)
+	}
+
+	for i, packet := range packets {
+		if string(packet) != expected[i] {
+			t.Errorf("expected packet %d to be %q, got %q", i, expected[i], packet)
+		}
+	}
+}
+
+func TestStatsdExporter_Shutdown(t *testing.T) {
+	listener := newTestStatsdListener(t)
+
+	exporter, err := newStatsdExporter(&OTLPConfig{StatsdAddress: listener.LocalAddr().String()})
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shutdown exporter: %v", err)
+	}
+
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Errorf("expected no error of the second shutdown, got: %v", err)
+	}
+
+	err = exporter.Export(context.Background(), &metricdata.ResourceMetrics{})
+	if !errors.Is(err, errStatsdExporterShutdown) {
+		t.Errorf("expected error %v, got: %v", errStatsdExporterShutdown, err)
+	}
+}
@@ -25,6 +25,16 @@ func getDefaultPtr[T any](value *T, defaultValue *T) *T {
 	return value
 }
 
+// returns the value or the default one if the value is empty.
+func getDefault[T comparable](value T, defaultValue T) T {
+	var zero T
+	if value == zero {
+		return defaultValue
+	}
+
+	return value
+}
+
 func getRequestID(r *http.Request) string {
 	requestID := r.Header.Get("x-request-id")
 	if requestID != "" {
@@ -38,6 +38,22 @@ func TestGetDefaultPtr(t *testing.T) {
 	})
 }
 
+func TestGetDefault(t *testing.T) {
+	t.Run("returns value when not empty", func(t *testing.T) {
+		result := getDefault("test", "default")
+		if result != "test" {
+			t.Errorf("expected 'test', got %s", result)
+		}
+	})
+
+	t.Run("returns default when value is empty", func(t *testing.T) {
+		result := getDefault("", "default")
+		if result != "default" {
+			t.Errorf("expected 'default', got %s", result)
+		}
+	})
+}
+
 func TestGetRequestID(t *testing.T) {
 	t.Run("returns x-request-id header when present", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/test", nil)